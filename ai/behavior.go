@@ -0,0 +1,253 @@
+package ai
+
+import (
+	"game/enum/NodeStatus"
+	"game/gamehandler"
+)
+
+// Node is a single node of a behavior tree
+//
+// Tick should return a status from the NodeStatus enum
+type Node interface {
+	Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8
+}
+
+// resetter is a node that remembers which of its children is running
+//
+// reset is called when the parent node stops ticking it before it has finished, so it starts from its first child the next time it is ticked
+type resetter interface {
+	reset()
+}
+
+// reset resets a node if it remembers a running child
+func reset(node Node){
+	if r, ok := node.(resetter); ok {
+		r.reset()
+	}
+}
+
+type actionNode struct {
+	cb func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8
+}
+
+func (node *actionNode) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+	return node.cb(game, thread)
+}
+
+// Action creates a node that runs a callback, and returns its status
+//
+// the callback should return a status from the NodeStatus enum
+func Action(cb func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8) Node {
+	return &actionNode{cb: cb}
+}
+
+// Condition creates a node that succeeds if the callback returns true, and fails if it returns false
+func Condition(cb func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) bool) Node {
+	return &actionNode{cb: func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+		if cb(game, thread) {
+			return NodeStatus.Success
+		}
+		return NodeStatus.Failure
+	}}
+}
+
+type sequenceNode struct {
+	children []Node
+	running int
+	memory bool
+}
+
+func (node *sequenceNode) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+	start := 0
+	if node.memory {
+		start = node.running
+	}
+
+	for i := start; i < len(node.children); i++ {
+		switch node.children[i].Tick(game, thread) {
+		case NodeStatus.Running:
+			node.abort(i)
+			node.running = i
+			return NodeStatus.Running
+		case NodeStatus.Failure:
+			node.abort(i)
+			node.running = 0
+			return NodeStatus.Failure
+		}
+	}
+
+	node.running = 0
+	return NodeStatus.Success
+}
+
+// abort resets the child that was running on the last tick, if a child before it has failed or is still running
+func (node *sequenceNode) abort(i int){
+	if node.running > i {
+		reset(node.children[node.running])
+	}
+}
+
+func (node *sequenceNode) reset(){
+	if node.running < len(node.children) {
+		reset(node.children[node.running])
+	}
+	node.running = 0
+}
+
+// Sequence creates a node that ticks its children in order until one of them fails
+//
+// the sequence starts from its first child on every tick, so a condition before a running child is checked again,
+// and the running child is reset if the condition fails
+//
+// succeeds if all of the children succeed
+func Sequence(children ...Node) Node {
+	return &sequenceNode{children: children}
+}
+
+// MemSequence creates a node that ticks its children in order until one of them fails
+//
+// unlike Sequence, if a child is still running, the sequence will continue from that child on the next tick
+//
+// succeeds if all of the children succeed
+func MemSequence(children ...Node) Node {
+	return &sequenceNode{children: children, memory: true}
+}
+
+type selectorNode struct {
+	children []Node
+	running int
+	memory bool
+}
+
+func (node *selectorNode) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+	start := 0
+	if node.memory {
+		start = node.running
+	}
+
+	for i := start; i < len(node.children); i++ {
+		switch node.children[i].Tick(game, thread) {
+		case NodeStatus.Running:
+			node.abort(i)
+			node.running = i
+			return NodeStatus.Running
+		case NodeStatus.Success:
+			node.abort(i)
+			node.running = 0
+			return NodeStatus.Success
+		}
+	}
+
+	node.running = 0
+	return NodeStatus.Failure
+}
+
+// abort resets the child that was running on the last tick, if a child before it has taken over
+func (node *selectorNode) abort(i int){
+	if node.running > i {
+		reset(node.children[node.running])
+	}
+}
+
+func (node *selectorNode) reset(){
+	if node.running < len(node.children) {
+		reset(node.children[node.running])
+	}
+	node.running = 0
+}
+
+// Selector creates a node that ticks its children in order until one of them succeeds
+//
+// the selector starts from its first child on every tick, so a child with a higher priority can take over from a child that is still running
+// (the child that was running is reset)
+//
+// fails if all of the children fail
+func Selector(children ...Node) Node {
+	return &selectorNode{children: children}
+}
+
+// MemSelector creates a node that ticks its children in order until one of them succeeds
+//
+// unlike Selector, if a child is still running, the selector will continue from that child on the next tick
+//
+// fails if all of the children fail
+func MemSelector(children ...Node) Node {
+	return &selectorNode{children: children, memory: true}
+}
+
+type inverterNode struct {
+	child Node
+}
+
+func (node *inverterNode) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+	switch node.child.Tick(game, thread) {
+	case NodeStatus.Success:
+		return NodeStatus.Failure
+	case NodeStatus.Failure:
+		return NodeStatus.Success
+	}
+	return NodeStatus.Running
+}
+
+func (node *inverterNode) reset(){
+	reset(node.child)
+}
+
+// Inverter creates a node that swaps the success and failure of its child
+func Inverter(child Node) Node {
+	return &inverterNode{child: child}
+}
+
+// Tree is a behavior tree that ticks its root node on a game loop
+type Tree struct {
+	// Root is the first node ticked by the tree
+	Root Node
+
+	// Status is the status returned by the root node on the last tick
+	Status uint8
+
+	detach func()
+}
+
+// NewTree creates a new behavior tree
+//
+// example (canSeePlayer is checked on every tick, so the enemy stops wandering as soon as it sees the player, and stops chasing as soon as it loses sight of the player):
+//
+//	tree := ai.NewTree(ai.Selector(
+//		ai.Sequence(ai.Condition(canSeePlayer), ai.Action(chasePlayer)),
+//		ai.Action(wander),
+//	)).Attach(enemy, Loop.UpdateSlow)
+func NewTree(root Node) *Tree {
+	return &Tree{Root: root}
+}
+
+// Attach ticks the behavior tree on a game loop of an object
+//
+// loop: the game loop to run on (from the Loop enum)
+func (tree *Tree) Attach(object *gamehandler.GameObject, loop uint8) *Tree {
+	tree.Detach()
+	tree.detach = object.AddUpdate(loop, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		tree.Tick(game, thread)
+	})
+	return tree
+}
+
+// Detach stops the behavior tree from ticking on the object it was attached to
+func (tree *Tree) Detach(){
+	if tree.detach != nil {
+		tree.detach()
+		tree.detach = nil
+	}
+}
+
+// Tick ticks the root node of the tree
+//
+// this is called automatically when the tree is attached to an object
+func (tree *Tree) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+	if tree.Root == nil {
+		return NodeStatus.Failure
+	}
+
+	tree.Status = tree.Root.Tick(game, thread)
+	return tree.Status
+}
@@ -0,0 +1,150 @@
+package ai
+
+import (
+	"game/enum/NodeStatus"
+	"game/gamehandler"
+	"testing"
+)
+
+// scriptNode returns a list of results, one on each tick, and keeps returning the last result when the list runs out
+type scriptNode struct {
+	name string
+	log *[]string
+	results []uint8
+	ticks int
+	resets int
+}
+
+func (node *scriptNode) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo) uint8 {
+	*node.log = append(*node.log, node.name)
+
+	i := node.ticks
+	if i >= len(node.results) {
+		i = len(node.results) - 1
+	}
+	node.ticks++
+	return node.results[i]
+}
+
+func (node *scriptNode) reset(){
+	node.resets++
+}
+
+func script(log *[]string, name string, results ...uint8) *scriptNode {
+	return &scriptNode{name: name, log: log, results: results}
+}
+
+// tick ticks a node, and checks its status and the children that were ticked
+func tick(t *testing.T, node Node, log *[]string, status uint8, ticked ...string) {
+	t.Helper()
+
+	*log = nil
+	if s := node.Tick(nil, nil); s != status {
+		t.Errorf("expected status %d, found %d", status, s)
+	}
+	expectLog(t, *log, ticked...)
+}
+
+func TestSequenceReactive(t *testing.T) {
+	log := []string{}
+	cond := script(&log, "cond", NodeStatus.Success, NodeStatus.Success, NodeStatus.Failure)
+	act := script(&log, "act", NodeStatus.Running)
+	seq := Sequence(cond, act)
+
+	tick(t, seq, &log, NodeStatus.Running, "cond", "act")
+
+	// the condition is checked again before the running child
+	tick(t, seq, &log, NodeStatus.Running, "cond", "act")
+	if act.resets != 0 {
+		t.Errorf("running child was reset %d times", act.resets)
+	}
+
+	tick(t, seq, &log, NodeStatus.Failure, "cond")
+	if act.resets != 1 {
+		t.Errorf("expected the running child to be reset once after the condition failed, found %d", act.resets)
+	}
+}
+
+func TestMemSequence(t *testing.T) {
+	log := []string{}
+	cond := script(&log, "cond", NodeStatus.Success, NodeStatus.Failure)
+	act := script(&log, "act", NodeStatus.Running, NodeStatus.Running, NodeStatus.Success)
+	next := script(&log, "next", NodeStatus.Success)
+	seq := MemSequence(cond, act, next)
+
+	tick(t, seq, &log, NodeStatus.Running, "cond", "act")
+
+	// continues from the running child without checking the condition
+	tick(t, seq, &log, NodeStatus.Running, "act")
+	tick(t, seq, &log, NodeStatus.Success, "act", "next")
+
+	// starts from the first child after finishing
+	tick(t, seq, &log, NodeStatus.Failure, "cond")
+
+	if act.resets != 0 {
+		t.Errorf("running child was reset %d times", act.resets)
+	}
+}
+
+func TestSelectorReactive(t *testing.T) {
+	log := []string{}
+	high := script(&log, "high", NodeStatus.Failure, NodeStatus.Failure, NodeStatus.Success)
+	low := script(&log, "low", NodeStatus.Running)
+	sel := Selector(high, low)
+
+	tick(t, sel, &log, NodeStatus.Running, "high", "low")
+
+	// the higher priority child is checked again before the running child
+	tick(t, sel, &log, NodeStatus.Running, "high", "low")
+	if low.resets != 0 {
+		t.Errorf("running child was reset %d times", low.resets)
+	}
+
+	tick(t, sel, &log, NodeStatus.Success, "high")
+	if low.resets != 1 {
+		t.Errorf("expected the running child to be reset once after a higher priority child took over, found %d", low.resets)
+	}
+}
+
+func TestMemSelector(t *testing.T) {
+	log := []string{}
+	high := script(&log, "high", NodeStatus.Failure, NodeStatus.Success)
+	low := script(&log, "low", NodeStatus.Running, NodeStatus.Running, NodeStatus.Failure)
+	last := script(&log, "last", NodeStatus.Success)
+	sel := MemSelector(high, low, last)
+
+	tick(t, sel, &log, NodeStatus.Running, "high", "low")
+
+	// continues from the running child without checking the higher priority child
+	tick(t, sel, &log, NodeStatus.Running, "low")
+	tick(t, sel, &log, NodeStatus.Success, "low", "last")
+
+	// starts from the first child after finishing
+	tick(t, sel, &log, NodeStatus.Success, "high")
+
+	if low.resets != 0 {
+		t.Errorf("running child was reset %d times", low.resets)
+	}
+}
+
+func TestInverter(t *testing.T) {
+	log := []string{}
+	child := script(&log, "child", NodeStatus.Success, NodeStatus.Failure, NodeStatus.Running)
+	inv := Inverter(child)
+
+	tick(t, inv, &log, NodeStatus.Failure, "child")
+	tick(t, inv, &log, NodeStatus.Success, "child")
+
+	// running is passed through unchanged
+	tick(t, inv, &log, NodeStatus.Running, "child")
+
+	// resetting the inverter resets its child
+	cond := script(&log, "cond", NodeStatus.Success, NodeStatus.Failure)
+	seq := Sequence(cond, inv)
+
+	tick(t, seq, &log, NodeStatus.Running, "cond", "child")
+	tick(t, seq, &log, NodeStatus.Failure, "cond")
+	if child.resets != 1 {
+		t.Errorf("expected the child of the inverter to be reset once, found %d", child.resets)
+	}
+}
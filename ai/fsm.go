@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"game/gamehandler"
+)
+
+// State is a single state of a finite state machine
+//
+// all of the callbacks are optional
+type State struct {
+	// Enter runs once when the state machine changes to this state
+	Enter func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)
+
+	// Update runs on every tick while this is the current state
+	Update func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)
+
+	// Exit runs once when the state machine changes away from this state
+	Exit func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)
+}
+
+// FSM is a finite state machine, which runs one state at a time
+type FSM struct {
+	states map[string]*State
+	current string
+	previous string
+	next string
+	changing bool
+
+	detach func()
+}
+
+// NewFSM creates a new finite state machine
+//
+// initial: the name of the first state to enter
+func NewFSM(initial string) *FSM {
+	return &FSM{
+		states: map[string]*State{},
+		next: initial,
+		changing: true,
+	}
+}
+
+// Add adds a new state to the state machine
+func (fsm *FSM) Add(name string, state State) *FSM {
+	fsm.states[name] = &state
+	return fsm
+}
+
+// Set changes to a different state on the next tick
+//
+// the Exit callback of the current state will run, followed by the Enter callback of the new state
+func (fsm *FSM) Set(name string){
+	fsm.next = name
+	fsm.changing = true
+}
+
+// Current returns the name of the current state
+func (fsm *FSM) Current() string {
+	return fsm.current
+}
+
+// Previous returns the name of the state before the current state
+func (fsm *FSM) Previous() string {
+	return fsm.previous
+}
+
+// Attach ticks the state machine on a game loop of an object
+//
+// loop: the game loop to run on (from the Loop enum)
+//
+// example: fsm.Attach(enemy, Loop.UpdateSlow)
+func (fsm *FSM) Attach(object *gamehandler.GameObject, loop uint8) *FSM {
+	fsm.Detach()
+	fsm.detach = object.AddUpdate(loop, fsm.Tick)
+	return fsm
+}
+
+// Detach stops the state machine from ticking on the object it was attached to
+func (fsm *FSM) Detach(){
+	if fsm.detach != nil {
+		fsm.detach()
+		fsm.detach = nil
+	}
+}
+
+// Tick changes states if needed, then updates the current state
+//
+// this is called automatically when the state machine is attached to an object
+func (fsm *FSM) Tick(game *gamehandler.Game, thread *gamehandler.ThreadInfo){
+	// a state may change again while entering, so keep going until the state settles
+	for i := 0; fsm.changing && i < 10; i++ {
+		fsm.changing = false
+
+		if state, ok := fsm.states[fsm.current]; ok && state.Exit != nil && fsm.current != "" {
+			state.Exit(game, thread)
+		}
+
+		fsm.previous = fsm.current
+		fsm.current = fsm.next
+
+		if state, ok := fsm.states[fsm.current]; ok && state.Enter != nil {
+			state.Enter(game, thread)
+		}
+	}
+
+	if state, ok := fsm.states[fsm.current]; ok && state.Update != nil {
+		state.Update(game, thread)
+	}
+}
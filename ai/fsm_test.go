@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"game/gamehandler"
+	"reflect"
+	"testing"
+)
+
+// logState returns a state that adds each of its callbacks to a log
+func logState(log *[]string, name string) State {
+	return State{
+		Enter: func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+			*log = append(*log, "enter " + name)
+		},
+		Update: func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+			*log = append(*log, "update " + name)
+		},
+		Exit: func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+			*log = append(*log, "exit " + name)
+		},
+	}
+}
+
+func expectLog(t *testing.T, log []string, expect ...string) {
+	t.Helper()
+
+	if len(log) == 0 && len(expect) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(log, expect) {
+		t.Errorf("expected %q, found %q", expect, log)
+	}
+}
+
+func TestFSMOrder(t *testing.T) {
+	log := []string{}
+	fsm := NewFSM("a").Add("a", logState(&log, "a")).Add("b", logState(&log, "b"))
+
+	if fsm.Current() != "" {
+		t.Errorf("entered %q before the first tick", fsm.Current())
+	}
+
+	fsm.Tick(nil, nil)
+	expectLog(t, log, "enter a", "update a")
+
+	log = nil
+	fsm.Tick(nil, nil)
+	expectLog(t, log, "update a")
+
+	log = nil
+	fsm.Set("b")
+	expectLog(t, log)
+	fsm.Tick(nil, nil)
+	expectLog(t, log, "exit a", "enter b", "update b")
+
+	if fsm.Current() != "b" || fsm.Previous() != "a" {
+		t.Errorf("expected current b and previous a, found %q and %q", fsm.Current(), fsm.Previous())
+	}
+}
+
+func TestFSMSetDuringUpdate(t *testing.T) {
+	log := []string{}
+	fsm := NewFSM("a")
+
+	a := logState(&log, "a")
+	a.Update = func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		log = append(log, "update a")
+		fsm.Set("b")
+	}
+	fsm.Add("a", a).Add("b", logState(&log, "b"))
+
+	// the new state is entered on the next tick
+	fsm.Tick(nil, nil)
+	expectLog(t, log, "enter a", "update a")
+	if fsm.Current() != "a" {
+		t.Errorf("changed to %q during update", fsm.Current())
+	}
+
+	log = nil
+	fsm.Tick(nil, nil)
+	expectLog(t, log, "exit a", "enter b", "update b")
+}
+
+func TestFSMSetDuringEnter(t *testing.T) {
+	log := []string{}
+	fsm := NewFSM("a")
+
+	a := logState(&log, "a")
+	a.Enter = func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		log = append(log, "enter a")
+		fsm.Set("b")
+	}
+	fsm.Add("a", a).Add("b", logState(&log, "b"))
+
+	// the state settles on the same tick, and only the final state is updated
+	fsm.Tick(nil, nil)
+	expectLog(t, log, "enter a", "exit a", "enter b", "update b")
+	if fsm.Current() != "b" || fsm.Previous() != "a" {
+		t.Errorf("expected current b and previous a, found %q and %q", fsm.Current(), fsm.Previous())
+	}
+}
@@ -0,0 +1,289 @@
+// Command gamegen creates the boilerplate for new game objects
+//
+// usage: go run ./cmd/gamegen object <Name> [flags]
+//
+// example: go run ./cmd/gamegen object Enemy --type object --collision box --border bounce
+//
+// this writes a new file to src/<name>.go, and creates an asset folder in assets/objects/<name>
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// borderMethods is the list of names that can be used with the --border flag
+var borderMethods = map[string]string{
+	"ignore": "Ignore",
+	"limit": "Limit",
+	"hide": "Hide",
+	"pushlimit": "PushLimit",
+	"pushhide": "PushHide",
+	"bounce": "Bounce",
+	"teleport": "Teleport",
+	"removeobject": "RemoveObject",
+	"remove": "RemoveObject",
+}
+
+// collisionMethods is the list of names that can be used with the --collision flag
+var collisionMethods = map[string]string{
+	"ghost": "Ghost",
+	"none": "Ghost",
+	"box": "Box",
+	"radius": "Radius",
+}
+
+// callbackNames is the list of names that can be used with the --callbacks flag
+var callbackNames = map[string]string{
+	"update": "Update",
+	"updateslow": "UpdateSlow",
+	"slow": "UpdateSlow",
+	"updatebasic": "UpdateBasic",
+	"basic": "UpdateBasic",
+	"draw": "Draw",
+}
+
+var callbackOrder = []string{"Update", "UpdateSlow", "UpdateBasic", "Draw"}
+
+var validName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// knownOS and knownArch are the GOOS and GOARCH values go uses as build constraints in file names
+//
+// a file named like "enemy_linux.go" or "enemy_arm64.go" would only be built for that OS or architecture
+var knownOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true, "illumos": true, "ios": true, "js": true,
+	"linux": true, "nacl": true, "netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true,
+}
+var knownArch = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true, "arm64be": true, "loong64": true,
+	"mips": true, "mipsle": true, "mips64": true, "mips64le": true, "mips64p32": true, "mips64p32le": true,
+	"ppc": true, "ppc64": true, "ppc64le": true, "riscv": true, "riscv64": true, "s390": true, "s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+}
+
+// objectData is the data used by the object template
+type objectData struct {
+	ObjName string
+	Type string
+	Size float32
+	FPS uint16
+	Collision string
+	Border string
+	Callbacks []string
+	AssetDir string
+}
+
+func main(){
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "gamegen:", err)
+		os.Exit(1)
+	}
+}
+
+func usage(fs *flag.FlagSet){
+	fmt.Fprintln(fs.Output(), "usage: go run ./cmd/gamegen object <Name> [flags]")
+	fmt.Fprintln(fs.Output(), "")
+	fmt.Fprintln(fs.Output(), "example: go run ./cmd/gamegen object Enemy --type object --collision box --border bounce")
+	fmt.Fprintln(fs.Output(), "")
+	fs.PrintDefaults()
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("gamegen", flag.ContinueOnError)
+	objType := fs.String("type", "object", "the object type (must be one of the ObjectTypes in src/config.yml)")
+	collision := fs.String("collision", "box", "the collision method (ghost, box, radius)")
+	border := fs.String("border", "limit", "the border method (ignore, limit, hide, pushlimit, pushhide, bounce, teleport, removeobject)")
+	callbacks := fs.String("callbacks", "update", "a comma separated list of callbacks to add (update, updateslow, updatebasic, draw)")
+	fps := fs.Uint("fps", 60, "the preferred FPS of the object")
+	size := fs.Float64("size", 4, "the width and height of the object")
+	root := fs.String("root", ".", "the root directory of the game")
+	force := fs.Bool("force", false, "overwrite the src file if it already exists")
+	fs.Usage = func() { usage(fs) }
+
+	// allow flags before and after the positional arguments
+	pos := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(pos) != 2 || pos[0] != "object" {
+		usage(fs)
+		return fmt.Errorf("expected 'object <Name>'")
+	}
+
+	name := pos[1]
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid object name %q: must start with a letter, and only contain letters, numbers, and underscores", name)
+	}
+
+	if err := checkFileName(snakeCase(name)); err != nil {
+		return fmt.Errorf("invalid object name %q: %w", name, err)
+	}
+
+	if *fps < 1 || *fps > 65535 {
+		return fmt.Errorf("invalid fps %d: must be between 1 and 65535", *fps)
+	}
+
+	if math.IsNaN(*size) || *size <= 0 || *size > math.MaxFloat32 {
+		return fmt.Errorf("invalid size %g: must be a number greater than 0, that fits in a float32", *size)
+	}
+
+	data := objectData{
+		ObjName: snakeCase(name),
+		Type: *objType,
+		Size: float32(*size),
+		FPS: uint16(*fps),
+	}
+	data.AssetDir = "assets/objects/" + data.ObjName
+
+	var ok bool
+	if data.Collision, ok = collisionMethods[strings.ToLower(*collision)]; !ok {
+		return fmt.Errorf("unknown collision method %q (expected one of: %s)", *collision, keys(collisionMethods))
+	}
+	if data.Border, ok = borderMethods[strings.ToLower(*border)]; !ok {
+		return fmt.Errorf("unknown border method %q (expected one of: %s)", *border, keys(borderMethods))
+	}
+
+	used := map[string]bool{}
+	for _, cb := range strings.Split(*callbacks, ",") {
+		cb = strings.ToLower(strings.TrimSpace(cb))
+		if cb == "" {
+			continue
+		}
+		if c, ok := callbackNames[cb]; ok {
+			used[c] = true
+		}else{
+			return fmt.Errorf("unknown callback %q (expected one of: %s)", cb, keys(callbackNames))
+		}
+	}
+	for _, cb := range callbackOrder {
+		if used[cb] {
+			data.Callbacks = append(data.Callbacks, cb)
+		}
+	}
+
+	if types := configObjectTypes(*root); len(types) != 0 {
+		found := false
+		for _, t := range types {
+			if t == data.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fmt.Fprintf(os.Stderr, "gamegen: warning: the object type %q is not in the ObjectTypes list in src/config.yml\n", data.Type)
+		}
+	}
+
+	// render the src file
+	buf := bytes.NewBuffer(nil)
+	if err := objectTemplate.Execute(buf, data); err != nil {
+		return err
+	}
+	src := buf.Bytes()
+	if _, err := parser.ParseFile(token.NewFileSet(), "", src, parser.AllErrors); err != nil {
+		return fmt.Errorf("generated invalid code: %w", err)
+	}
+
+	srcPath := filepath.Join(*root, "src", data.ObjName + ".go")
+	if _, err := os.Stat(srcPath); err == nil && !*force {
+		return fmt.Errorf("%s already exists (use --force to overwrite it)", srcPath)
+	}
+
+	assetPath := filepath.Join(*root, filepath.FromSlash(data.AssetDir))
+	if err := os.MkdirAll(assetPath, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(assetPath, ".gitkeep"), []byte{}, 0644); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(srcPath, src, 0644); err != nil {
+		return err
+	}
+
+	fmt.Println("created", srcPath)
+	fmt.Println("created", assetPath)
+	fmt.Printf("add a sprite to %s/%s.png, or edit the renderer in %s\n", data.AssetDir, data.ObjName, srcPath)
+
+	return nil
+}
+
+// configObjectTypes returns the ObjectTypes list from src/config.yml
+//
+// returns nil if the config file could not be read
+func configObjectTypes(root string) []string {
+	buf, err := os.ReadFile(filepath.Join(root, "src", "config.yml"))
+	if err != nil {
+		return nil
+	}
+
+	config := struct {
+		ObjectTypes []string `yaml:"ObjectTypes"`
+	}{}
+	if err := yaml.Unmarshal(buf, &config); err != nil {
+		return nil
+	}
+
+	return config.ObjectTypes
+}
+
+// checkFileName returns an error if go would treat src/<name>.go as a test file, or only build it for some systems
+func checkFileName(name string) error {
+	parts := strings.Split(name, "_")
+	if len(parts) < 2 {
+		return nil
+	}
+
+	last := parts[len(parts)-1]
+	if last == "test" {
+		return fmt.Errorf("src/%s.go would be a test file (the name cannot end in _test)", name)
+	}
+	if knownOS[last] || knownArch[last] {
+		return fmt.Errorf("src/%s.go would only be built for %s (the name cannot end in a GOOS or GOARCH)", name, last)
+	}
+	return nil
+}
+
+// snakeCase converts a name like "BigEnemy" to "big_enemy"
+func snakeCase(name string) string {
+	res := []rune{}
+	r := []rune(name)
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			if i != 0 && r[i-1] != '_' && (unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]))) {
+				res = append(res, '_')
+			}
+			c = unicode.ToLower(c)
+		}
+		res = append(res, c)
+	}
+	return string(res)
+}
+
+func keys(m map[string]string) string {
+	list := []string{}
+	for k := range m {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,43 @@
+package main
+
+import "text/template"
+
+// objectTemplate is the src file created for a new object
+var objectTemplate = template.Must(template.New("object").Parse(`package game
+
+import (
+	"game/enum/BorderMethod"
+	"game/enum/CollisionMethod"
+	"game/gamehandler"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+func init(){
+	gamehandler.InitObject(func(game *gamehandler.Game) {
+		size := float32({{.Size}})
+
+		object := game.Add("{{.Type}}", "{{.ObjName}}", 0, 0, size, size, func(game *gamehandler.Game) fyne.CanvasObject {
+			if img, err := gamehandler.ReadFile("./{{.AssetDir}}/{{.ObjName}}.png"); err == nil {
+				res := canvas.NewImageFromResource(fyne.NewStaticResource("{{.ObjName}}", img))
+				return res
+			}
+
+			res := canvas.NewRectangle(color.White)
+			return res
+		})
+
+		object.PreferredFPS = {{.FPS}}
+
+		object.BorderMethod = BorderMethod.{{.Border}}
+		object.CollisionMethod = CollisionMethod.{{.Collision}}
+{{range .Callbacks}}
+		object.{{.}} = func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+			//todo: {{.}}
+
+		}
+{{end}}	})
+}
+`))
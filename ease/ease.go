@@ -0,0 +1,161 @@
+package ease
+
+import (
+	"math"
+)
+
+// Linear moves at a constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad starts slow and speeds up
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad starts fast and slows down
+func OutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// InOutQuad starts slow, speeds up, then slows down
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// InCubic starts slow and speeds up
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic starts fast and slows down
+func OutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// InOutCubic starts slow, speeds up, then slows down
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// InSine starts slow and speeds up along a sine curve
+func InSine(t float64) float64 {
+	return 1 - math.Cos((t*math.Pi)/2)
+}
+
+// OutSine starts fast and slows down along a sine curve
+func OutSine(t float64) float64 {
+	return math.Sin((t * math.Pi) / 2)
+}
+
+// InOutSine starts slow, speeds up, then slows down along a sine curve
+func InOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// InExpo starts very slow and speeds up quickly
+func InExpo(t float64) float64 {
+	if t == 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10)
+}
+
+// OutExpo starts very fast and slows down quickly
+func OutExpo(t float64) float64 {
+	if t == 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+// InOutExpo starts very slow, speeds up quickly, then slows down quickly
+func InOutExpo(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	if t < 0.5 {
+		return math.Pow(2, 20*t-10) / 2
+	}
+	return (2 - math.Pow(2, -20*t+10)) / 2
+}
+
+// InBack pulls back a little before moving forward
+func InBack(t float64) float64 {
+	c1 := 1.70158
+	c3 := c1 + 1
+	return c3*t*t*t - c1*t*t
+}
+
+// OutBack overshoots the end a little before settling
+func OutBack(t float64) float64 {
+	c1 := 1.70158
+	c3 := c1 + 1
+	return 1 + c3*math.Pow(t-1, 3) + c1*math.Pow(t-1, 2)
+}
+
+// InOutBack pulls back a little at the start, and overshoots a little at the end
+func InOutBack(t float64) float64 {
+	c2 := 1.70158 * 1.525
+	if t < 0.5 {
+		return (math.Pow(2*t, 2) * ((c2+1)*2*t - c2)) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((c2+1)*(t*2-2)+c2) + 2) / 2
+}
+
+// InElastic wobbles like a spring at the start
+func InElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	c4 := (2 * math.Pi) / 3
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*c4)
+}
+
+// OutElastic wobbles like a spring at the end
+func OutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	c4 := (2 * math.Pi) / 3
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*c4) + 1
+}
+
+// InBounce bounces at the start
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
+
+// OutBounce bounces at the end, like a falling ball
+func OutBounce(t float64) float64 {
+	n1 := 7.5625
+	d1 := 2.75
+
+	if t < 1/d1 {
+		return n1 * t * t
+	}else if t < 2/d1 {
+		t -= 1.5 / d1
+		return n1*t*t + 0.75
+	}else if t < 2.5/d1 {
+		t -= 2.25 / d1
+		return n1*t*t + 0.9375
+	}
+
+	t -= 2.625 / d1
+	return n1*t*t + 0.984375
+}
+
+// InOutBounce bounces at the start and the end
+func InOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - OutBounce(1-2*t)) / 2
+	}
+	return (1 + OutBounce(2*t-1)) / 2
+}
@@ -0,0 +1,19 @@
+//go:build embed
+
+package main
+
+import (
+	"embed"
+	"game/gamehandler"
+)
+
+// embedFS contains the config and asset files, so the game can be shipped as a single binary
+//
+// example: go build -tags embed
+//
+//go:embed src/config.yml assets
+var embedFS embed.FS
+
+func init(){
+	gamehandler.SetAssetFS(embedFS)
+}
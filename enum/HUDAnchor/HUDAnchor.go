@@ -0,0 +1,28 @@
+package HUDAnchor
+
+// TopLeft places a HUD element in the top left corner of the screen
+const TopLeft uint8 = 0
+
+// Top places a HUD element in the top center of the screen
+const Top uint8 = 1
+
+// TopRight places a HUD element in the top right corner of the screen
+const TopRight uint8 = 2
+
+// Left places a HUD element on the middle left side of the screen
+const Left uint8 = 3
+
+// Center places a HUD element in the center of the screen
+const Center uint8 = 4
+
+// Right places a HUD element on the middle right side of the screen
+const Right uint8 = 5
+
+// BottomLeft places a HUD element in the bottom left corner of the screen
+const BottomLeft uint8 = 6
+
+// Bottom places a HUD element in the bottom center of the screen
+const Bottom uint8 = 7
+
+// BottomRight places a HUD element in the bottom right corner of the screen
+const BottomRight uint8 = 8
@@ -0,0 +1,13 @@
+package Loop
+
+// Update is the normal 60 fps game loop
+const Update uint8 = 0
+
+// Draw is the graphical 120 fps game loop
+const Draw uint8 = 1
+
+// UpdateSlow is the slow 30 fps game loop
+const UpdateSlow uint8 = 2
+
+// UpdateBasic is the extra slow 15 fps game loop
+const UpdateBasic uint8 = 3
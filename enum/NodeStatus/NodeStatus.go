@@ -0,0 +1,10 @@
+package NodeStatus
+
+// Success means a behavior tree node finished what it was doing
+const Success uint8 = 0
+
+// Failure means a behavior tree node could not do what it was trying to do
+const Failure uint8 = 1
+
+// Running means a behavior tree node is still working, and should be ticked again on the next update
+const Running uint8 = 2
@@ -0,0 +1,25 @@
+package TweenProp
+
+// X tweens the x position of an object
+const X uint8 = 0
+
+// Y tweens the y position of an object
+const Y uint8 = 1
+
+// Width tweens the width of an object
+const Width uint8 = 2
+
+// Height tweens the height of an object
+const Height uint8 = 3
+
+// Alpha tweens the opacity of the canvas object of an object (between 0 and 1)
+const Alpha uint8 = 4
+
+// Rotation tweens the rotation of an object (in degrees)
+const Rotation uint8 = 5
+
+// ScaleX tweens the x scale of an object
+const ScaleX uint8 = 6
+
+// ScaleY tweens the y scale of an object
+const ScaleY uint8 = 7
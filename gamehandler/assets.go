@@ -0,0 +1,118 @@
+package gamehandler
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+var assetFS fs.FS
+var assetOverlays []fs.FS
+var assetFSMU sync.Mutex
+
+// SetAssetFS sets the file system that config and asset files are loaded from
+//
+// files that are not found in the file system are read from the disk instead
+//
+// note: this is set automatically when the game is built with the 'embed' build tag
+//
+// example: go build -tags embed
+func SetAssetFS(fsys fs.FS){
+	assetFSMU.Lock()
+	defer assetFSMU.Unlock()
+
+	assetFS = fsys
+}
+
+// AddAssetOverlay adds a file system that is checked before any other config and asset files
+//
+// overlays added later take priority over overlays added earlier, which allows mods to replace assets
+//
+// example: gamehandler.AddAssetOverlay(os.DirFS("./mods/my-mod"))
+func AddAssetOverlay(fsys fs.FS){
+	assetFSMU.Lock()
+	defer assetFSMU.Unlock()
+
+	assetOverlays = append(assetOverlays, fsys)
+}
+
+// HasAssetFS returns true if config and asset files are being loaded from an embedded file system
+func HasAssetFS() bool {
+	assetFSMU.Lock()
+	defer assetFSMU.Unlock()
+
+	return assetFS != nil
+}
+
+// ReadFile reads a config or asset file
+//
+// the name is relative to the root of the game (example: "./assets/icon.png")
+//
+// the file is read from the asset overlays first (see 'AddAssetOverlay'), then from the asset file system set with 'SetAssetFS'.
+// otherwise, the file is read from the disk, relative to the working directory, then relative to the directory of the executable
+func ReadFile(name string) ([]byte, error) {
+	assetFSMU.Lock()
+	list := []fs.FS{}
+	for i := len(assetOverlays) - 1; i >= 0; i-- {
+		list = append(list, assetOverlays[i])
+	}
+	if assetFS != nil {
+		list = append(list, assetFS)
+	}
+	assetFSMU.Unlock()
+
+	if fsName, ok := assetName(name); ok && !filepath.IsAbs(name) {
+		for _, fsys := range list {
+			if buf, err := fs.ReadFile(fsys, fsName); err == nil {
+				return buf, nil
+			}else if !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+		}
+	}
+
+	buf, err := os.ReadFile(name)
+	if err == nil || filepath.IsAbs(name) || !errors.Is(err, fs.ErrNotExist) {
+		return buf, err
+	}
+
+	// allow the game to run from outside of its own directory
+	if exe, e := os.Executable(); e == nil {
+		if buf, e := os.ReadFile(filepath.Join(filepath.Dir(exe), name)); e == nil {
+			return buf, nil
+		}
+	}
+
+	return nil, err
+}
+
+// LoadResource reads a config or asset file as a fyne resource
+//
+// example: res, err := gamehandler.LoadResource("./assets/icon.png")
+func LoadResource(name string) (fyne.Resource, error) {
+	buf, err := ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return fyne.NewStaticResource(path.Base(filepath.ToSlash(name)), buf), nil
+}
+
+// assetName converts a file name to a valid io/fs path
+//
+// example: "./assets/icon.png" -> "assets/icon.png"
+func assetName(name string) (string, bool) {
+	name = path.Clean(filepath.ToSlash(name))
+	name = strings.TrimPrefix(name, "/")
+
+	if !fs.ValidPath(name) || name == "." {
+		return "", false
+	}
+	return name, true
+}
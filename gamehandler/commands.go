@@ -0,0 +1,65 @@
+package gamehandler
+
+// IsRemoved returns true if an object has been removed from the game
+//
+// objects removed during a game loop are marked as removed right away,
+// but they are not taken out of the game until the current tick has finished
+func (object *GameObject) IsRemoved() bool {
+	object.game.objectsMU.Lock()
+	defer object.game.objectsMU.Unlock()
+
+	return object.removed
+}
+
+// command runs a change to the list of objects
+//
+// if a game loop is running, the change is queued and applied after the current tick has finished,
+// so the loop does not see the list change while it is looping through it
+func (game *Game) command(cb func()){
+	game.commandsMU.Lock()
+	if game.ticking != 0 {
+		game.commands = append(game.commands, cb)
+		game.commandsMU.Unlock()
+		return
+	}
+	game.commandsMU.Unlock()
+
+	cb()
+}
+
+// beginTick marks the start of a game loop tick
+//
+// any objects added or removed until 'endTick' will be queued
+func (game *Game) beginTick(){
+	game.commandsMU.Lock()
+	game.ticking++
+	game.commandsMU.Unlock()
+}
+
+// endTick marks the end of a game loop tick, and applies any queued changes
+func (game *Game) endTick(){
+	game.commandsMU.Lock()
+	game.ticking--
+	if game.ticking != 0 {
+		game.commandsMU.Unlock()
+		return
+	}
+
+	commands := game.commands
+	game.commands = nil
+	game.commandsMU.Unlock()
+
+	for _, cb := range commands {
+		cb()
+	}
+}
+
+// markRemoved marks an object and its children as removed
+//
+// objectsMU should be locked before calling this method
+func (object *GameObject) markRemoved(){
+	object.removed = true
+	for _, child := range object.children {
+		child.markRemoved()
+	}
+}
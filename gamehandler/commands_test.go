@@ -0,0 +1,96 @@
+package gamehandler_test
+
+import (
+	"game/enum/Loop"
+	"game/gamehandler"
+	"game/gamehandler/gametest"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// inLayer returns true if the canvas object of an object is in its render layer
+func inLayer(game *gamehandler.Game, object *gamehandler.GameObject) bool {
+	for _, obj := range game.CanvasList["object"].Objects {
+		if obj == object.Object {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCommandsDuringTick(t *testing.T) {
+	game := gametest.New(t)
+	spawner := newBox(game, "spawner", 0, 0)
+	victim := newBox(game, "victim", 10, 0)
+
+	// objects added outside of a tick are added right away
+	game.AssertCount("object", 2)
+	if !inLayer(game.Game, victim) {
+		t.Fatalf("expected the object to be added right away outside of a tick")
+	}
+
+	removed := 0
+	victim.OnRemove(func(game *gamehandler.Game, object *gamehandler.GameObject) {
+		removed++
+	})
+
+	var spawned *gamehandler.GameObject
+	spawner.AddUpdate(Loop.Update, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		if spawned != nil {
+			return
+		}
+
+		spawned = game.Add("object", "spawned", 20, 0, 4, 4, func(game *gamehandler.Game) fyne.CanvasObject {
+			return canvas.NewRectangle(color.White)
+		})
+		if len(game.GetType("object")) != 2 {
+			t.Errorf("the new object was added during the tick")
+		}
+
+		victim.Remove(game, thread)
+		if !victim.IsRemoved() {
+			t.Errorf("expected the object to be marked as removed right away")
+		}
+		if !inLayer(game, victim) {
+			t.Errorf("the object was taken out of its render layer during the tick")
+		}
+		if removed != 0 {
+			t.Errorf("the OnRemove hook ran during the tick")
+		}
+	})
+
+	// Update runs on the 2nd tick
+	game.Step(2)
+	if spawned == nil {
+		t.Fatalf("the update callback did not run")
+	}
+
+	game.AssertCount("object", 2)
+	for _, object := range game.GetType("object") {
+		if object == victim {
+			t.Errorf("the removed object is still in the game after the tick")
+		}
+	}
+	if !inLayer(game.Game, spawned) {
+		t.Errorf("the new object was not added to its render layer after the tick")
+	}
+	if inLayer(game.Game, victim) {
+		t.Errorf("the removed object is still in its render layer after the tick")
+	}
+	if removed != 1 {
+		t.Errorf("expected the OnRemove hook to run once after the tick, found %d", removed)
+	}
+
+	// the new object updates on the next tick
+	updated := false
+	spawned.AddUpdate(Loop.Update, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		updated = true
+	})
+	game.Step(2)
+	if !updated {
+		t.Errorf("the new object did not update after it was added")
+	}
+}
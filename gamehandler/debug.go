@@ -0,0 +1,293 @@
+package gamehandler
+
+import (
+	"fmt"
+	"game/enum/CollisionMethod"
+	"game/enum/Loop"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+var (
+	debugBoxColor = color.NRGBA{R: 0, G: 255, B: 0, A: 255}
+	debugRadiusColor = color.NRGBA{R: 0, G: 200, B: 255, A: 255}
+	debugGhostColor = color.NRGBA{R: 160, G: 160, B: 160, A: 140}
+	debugCollideColor = color.NRGBA{R: 255, G: 0, B: 0, A: 255}
+	debugVelColor = color.NRGBA{R: 255, G: 255, B: 0, A: 255}
+	debugBorderColor = color.NRGBA{R: 255, G: 128, B: 0, A: 255}
+)
+
+// debugOverlay draws hitboxes, velocities and stats above the game
+type debugOverlay struct {
+	box *fyne.Container
+	stats *widget.Label
+
+	lines []*canvas.Line
+	circles []*canvas.Circle
+	texts []*canvas.Text
+
+	line int
+	circle int
+	text int
+}
+
+// SetDebug shows or hides the debug overlay
+//
+// the overlay draws the hitbox of each object (red while colliding), velocity vectors, border state,
+// the number of objects of each type, and the current FPS of each game loop
+func (game *Game) SetDebug(enabled bool){
+	game.debugMU.Lock()
+	defer game.debugMU.Unlock()
+
+	game.debugEnabled = enabled
+
+	if game.debug == nil {
+		if !enabled {
+			return
+		}
+		game.debug = newDebugOverlay(game)
+	}
+
+	if enabled {
+		game.debug.box.Show()
+	}else{
+		game.debug.box.Hide()
+	}
+}
+
+// ToggleDebug shows the debug overlay if it is hidden, or hides it if it is shown
+//
+// example: window.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) { if key.Name == fyne.KeyF3 { game.ToggleDebug() } })
+func (game *Game) ToggleDebug(){
+	game.SetDebug(!game.IsDebug())
+}
+
+// IsDebug returns true if the debug overlay is shown
+func (game *Game) IsDebug() bool {
+	game.debugMU.Lock()
+	defer game.debugMU.Unlock()
+
+	return game.debugEnabled
+}
+
+// LoopFPS returns the current FPS of a game loop
+//
+// loop: the game loop from the Loop enum
+func (game *Game) LoopFPS(loop uint8) uint16 {
+	if loop > Loop.UpdateBasic {
+		return 0
+	}
+
+	game.timeMU.Lock()
+	defer game.timeMU.Unlock()
+
+	return game.loopFPS[loop]
+}
+
+func newDebugOverlay(game *Game) *debugOverlay {
+	debug := debugOverlay{
+		box: container.NewWithoutLayout(),
+		stats: widget.NewLabel(""),
+	}
+
+	debug.stats.TextStyle = fyne.TextStyle{Monospace: true}
+	debug.box.Add(debug.stats)
+
+	// the HUD covers the same area as the game canvas, so the overlay can use the same positions
+	if game.HUD != nil {
+		game.HUD.Container.Objects = append([]fyne.CanvasObject{debug.box}, game.HUD.Container.Objects...)
+		game.HUD.Container.Refresh()
+	}else if game.Canvas != nil {
+		game.Canvas.Add(debug.box)
+		game.Canvas.Refresh()
+	}
+
+	return &debug
+}
+
+// drawDebug updates the debug overlay
+//
+// this method will be called by the draw method
+func (game *Game) drawDebug(){
+	game.debugMU.Lock()
+	defer game.debugMU.Unlock()
+
+	if !game.debugEnabled || game.debug == nil {
+		return
+	}
+
+	debug := game.debug
+	debug.line = 0
+	debug.circle = 0
+	debug.text = 0
+
+	objects := game.Query().All()
+	counts := map[string]int{}
+
+	for _, object := range objects {
+		counts[object.objType]++
+
+		if object.IsHidden() {
+			continue
+		}
+
+		b := object.hitbox()
+
+		col := debugGhostColor
+		if object.CollisionMethod == CollisionMethod.Box {
+			col = debugBoxColor
+		}else if object.CollisionMethod == CollisionMethod.Radius {
+			col = debugRadiusColor
+		}
+
+		if object.CollisionMethod != CollisionMethod.Ghost && len(object.IsColideingAny()) != 0 {
+			col = debugCollideColor
+		}
+
+		// draw the hitbox
+		if object.CollisionMethod == CollisionMethod.Radius {
+			size := float32(math.Sqrt(math.Pow(float64(b.Width), 2) + math.Pow(float64(b.Height), 2))) / (math.Pi / 2.25)
+			circle := debug.nextCircle()
+			circle.StrokeColor = col
+			circle.Move(game.debugPos(b.X - size, b.Y - size))
+			circle.Resize(fyne.NewSize(size * 2 * game.Size.Scale, size * 2 * game.Size.Scale))
+			circle.Refresh()
+		}else{
+			corners := [4][2]float32{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+			for i := range corners {
+				corners[i][0], corners[i][1] = rotatePoint(corners[i][0] * b.Width, corners[i][1] * b.Height, b.Rotation)
+			}
+
+			for i := range corners {
+				next := corners[(i + 1) % 4]
+				debug.drawLine(game, col, b.X + corners[i][0], b.Y + corners[i][1], b.X + next[0], b.Y + next[1])
+			}
+		}
+
+		// draw the velocity vector
+		if object.VelX != 0 || object.VelY != 0 {
+			debug.drawLine(game, debugVelColor, b.X, b.Y, b.X + object.VelX, b.Y + object.VelY)
+		}
+
+		// draw the border state
+		if object.OnBorderX != 0 || object.OnBorderY != 0 {
+			width, height := b.extents()
+			text := debug.nextText()
+			text.Text = fmt.Sprintf("%d,%d", object.OnBorderX, object.OnBorderY)
+			text.Move(game.debugPos(b.X - width, b.Y + height))
+			text.Resize(text.MinSize())
+			text.Refresh()
+		}
+	}
+
+	debug.stats.SetText(game.debugStats(counts))
+	debug.stats.Move(fyne.NewPos(4, 4))
+	debug.stats.Resize(debug.stats.MinSize())
+
+	debug.hideUnused()
+}
+
+// debugStats returns the text for the stats panel of the debug overlay
+func (game *Game) debugStats(counts map[string]int) string {
+	game.timeMU.Lock()
+	fps := game.loopFPS
+	game.timeMU.Unlock()
+
+	lines := []string{
+		fmt.Sprintf("Draw:        %3d fps", fps[Loop.Draw]),
+		fmt.Sprintf("Update:      %3d fps", fps[Loop.Update]),
+		fmt.Sprintf("UpdateSlow:  %3d fps", fps[Loop.UpdateSlow]),
+		fmt.Sprintf("UpdateBasic: %3d fps", fps[Loop.UpdateBasic]),
+		"",
+	}
+
+	types := []string{}
+	total := 0
+	for objType, count := range counts {
+		types = append(types, objType)
+		total += count
+	}
+	sort.Strings(types)
+
+	for _, objType := range types {
+		lines = append(lines, fmt.Sprintf("%s: %d", objType, counts[objType]))
+	}
+	lines = append(lines, fmt.Sprintf("total: %d", total))
+
+	return strings.Join(lines, "\n")
+}
+
+// debugPos converts a position in game units to a position on the screen
+func (game *Game) debugPos(x, y float32) fyne.Position {
+	return fyne.NewPos((x * game.Size.Scale) + (game.Size.RealWidth/2), (y * game.Size.Scale) + (game.Size.RealHeight/2))
+}
+
+func (debug *debugOverlay) drawLine(game *Game, col color.Color, x1, y1, x2, y2 float32){
+	line := debug.nextLine()
+	line.StrokeColor = col
+	line.Position1 = game.debugPos(x1, y1)
+	line.Position2 = game.debugPos(x2, y2)
+	line.Refresh()
+}
+
+func (debug *debugOverlay) nextLine() *canvas.Line {
+	if debug.line == len(debug.lines) {
+		line := canvas.NewLine(color.White)
+		line.StrokeWidth = 1
+		debug.lines = append(debug.lines, line)
+		debug.box.Add(line)
+	}
+
+	line := debug.lines[debug.line]
+	line.Show()
+	debug.line++
+	return line
+}
+
+func (debug *debugOverlay) nextCircle() *canvas.Circle {
+	if debug.circle == len(debug.circles) {
+		circle := canvas.NewCircle(color.Transparent)
+		circle.StrokeWidth = 1
+		debug.circles = append(debug.circles, circle)
+		debug.box.Add(circle)
+	}
+
+	circle := debug.circles[debug.circle]
+	circle.Show()
+	debug.circle++
+	return circle
+}
+
+func (debug *debugOverlay) nextText() *canvas.Text {
+	if debug.text == len(debug.texts) {
+		text := canvas.NewText("", debugBorderColor)
+		text.TextSize = 10
+		debug.texts = append(debug.texts, text)
+		debug.box.Add(text)
+	}
+
+	text := debug.texts[debug.text]
+	text.Show()
+	debug.text++
+	return text
+}
+
+// hideUnused hides any shapes that were not used during the last draw
+func (debug *debugOverlay) hideUnused(){
+	for _, line := range debug.lines[debug.line:] {
+		line.Hide()
+	}
+	for _, circle := range debug.circles[debug.circle:] {
+		circle.Hide()
+	}
+	for _, text := range debug.texts[debug.text:] {
+		text.Hide()
+	}
+}
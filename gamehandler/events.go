@@ -0,0 +1,133 @@
+package gamehandler
+
+import (
+	"game/enum/Loop"
+)
+
+// Subscription is a handler listening for a game event
+type Subscription struct {
+	game *Game
+	event string
+	loop uint8
+	handler func(game *Game, thread *ThreadInfo, data any)
+	canceled bool
+}
+
+// Event is a typed game event
+//
+// example:
+//
+//	var PlayerDied = gamehandler.NewEvent[*gamehandler.GameObject]("PlayerDied")
+//
+//	PlayerDied.Subscribe(game, Loop.Update, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo, player *gamehandler.GameObject) {})
+//	PlayerDied.Publish(game, player)
+type Event[T any] struct {
+	// Name is the name of the event used by the event bus
+	Name string
+}
+
+type queuedEvent struct {
+	sub *Subscription
+	data any
+}
+
+// NewEvent creates a new typed game event
+func NewEvent[T any](name string) Event[T] {
+	return Event[T]{Name: name}
+}
+
+// Subscribe adds a handler for this event
+//
+// the handler runs on the chosen game loop while the game is locked (from the Loop enum)
+//
+// if the event is published with data that is not a T (with the untyped 'game.Publish'), the handler is skipped
+func (event Event[T]) Subscribe(game *Game, loop uint8, handler func(game *Game, thread *ThreadInfo, data T)) *Subscription {
+	return game.Subscribe(event.Name, loop, func(game *Game, thread *ThreadInfo, data any) {
+		d, ok := data.(T)
+		if !ok && data != nil {
+			return
+		}
+		handler(game, thread, d)
+	})
+}
+
+// Publish sends this event to all of its handlers
+func (event Event[T]) Publish(game *Game, data T){
+	game.Publish(event.Name, data)
+}
+
+// Subscribe adds a handler for a game event
+//
+// the handler runs on the chosen game loop while the game is locked (from the Loop enum)
+//
+// for type safe data, you can use 'NewEvent' instead
+func (game *Game) Subscribe(event string, loop uint8, handler func(game *Game, thread *ThreadInfo, data any)) *Subscription {
+	if int(loop) >= len(game.eventQueue) {
+		loop = Loop.Update
+	}
+
+	sub := Subscription{
+		game: game,
+		event: event,
+		loop: loop,
+		handler: handler,
+	}
+
+	game.eventsMU.Lock()
+	defer game.eventsMU.Unlock()
+
+	if game.events == nil {
+		game.events = map[string][]*Subscription{}
+	}
+	game.events[event] = append(game.events[event], &sub)
+
+	return &sub
+}
+
+// Publish sends a game event to all of its handlers
+//
+// the handlers will run the next time their game loop runs, so this is safe to call from anywhere
+func (game *Game) Publish(event string, data any){
+	game.eventsMU.Lock()
+	defer game.eventsMU.Unlock()
+
+	for _, sub := range game.events[event] {
+		game.eventQueue[sub.loop] = append(game.eventQueue[sub.loop], queuedEvent{sub: sub, data: data})
+	}
+}
+
+// Unsubscribe removes a handler, so it will no longer run
+//
+// any events that were already published will also be skipped
+func (sub *Subscription) Unsubscribe(){
+	sub.game.eventsMU.Lock()
+	defer sub.game.eventsMU.Unlock()
+
+	sub.canceled = true
+
+	list := sub.game.events[sub.event]
+	for i, s := range list {
+		if s == sub {
+			sub.game.events[sub.event] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+}
+
+// dispatchEvents runs the handlers for any published events on a game loop
+func (game *Game) dispatchEvents(loop uint8, thread *ThreadInfo){
+	game.eventsMU.Lock()
+	queue := game.eventQueue[loop]
+	game.eventQueue[loop] = nil
+	game.eventsMU.Unlock()
+
+	for _, e := range queue {
+		game.eventsMU.Lock()
+		canceled := e.sub.canceled
+		game.eventsMU.Unlock()
+
+		if !canceled {
+			e.sub.handler(game, thread, e.data)
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package gamehandler
 
 import (
+	"context"
 	"game/enum/BorderMethod"
 	"game/enum/CollisionMethod"
+	"game/enum/Loop"
 	"game/enum/TypeCollisionMethod"
+	"image"
 	"math"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"github.com/AspieSoft/goutil/v5"
@@ -38,6 +43,32 @@ type ThreadInfo struct {
 
 	// SpeedDelta can be multiplied by a consistently updating number that needs to recalculate if the max FPS is decreased in config.yml
 	SpeedDelta float32
+
+	// TimeScale is the current speed of game time, set by 'game.SetTimeScale'
+	//
+	// this can be multiplied by a consistently updating number (along with SpeedDelta) to allow slow motion
+	//
+	// this will be 0 while the game is paused
+	TimeScale float32
+
+	// Paused is true if the game is currently paused
+	Paused bool
+
+	// Delta is the amount of game time that passes during each frame of this thread
+	//
+	// this includes the TimeScale, and will be 0 while the game is paused
+	Delta time.Duration
+
+	// LockWait is how long the game loop waited for game.MU before this tick started
+	LockWait time.Duration
+
+	// Late is the number of frames this tick started behind schedule
+	Late uint16
+
+	// Skipped is the number of frames that were skipped before this tick, because the game loop fell too far behind
+	Skipped uint16
+
+	delta time.Duration
 }
 
 type Game struct {
@@ -48,20 +79,120 @@ type Game struct {
 	Window fyne.Window
 	Size CanvasSize
 
+	// HUD is a screen space overlay for text, counters, bars and menus
+	HUD *HUD
+
 	MaxFPS uint16
 	InconsistentRand bool
 
 	MU sync.Mutex
+
+	paused bool
+	timeScale float32
+	timeScaleSet bool
+	timeMU sync.Mutex
+
+	time time.Duration
+	timers []*Timer
+	timersMU sync.Mutex
+
+	tweens []*Tween
+	tweensMU sync.Mutex
+
+	events map[string][]*Subscription
+	eventQueue [4][]queuedEvent
+	eventsMU sync.Mutex
+
+	layerSort map[string]bool
+	layerZIndex map[string]bool
+	objectOrder uint64
+
+	loopFPS [4]uint16
+	debug *debugOverlay
+	debugEnabled bool
+	debugMU sync.Mutex
+
+	profiling atomic.Bool
+	profileStart time.Time
+	loopProfile [4]LoopProfile
+	objectProfile map[*GameObject]*[4]callProfile
+	profileMU sync.Mutex
+
+	ctx context.Context
+	cancel context.CancelFunc
+	running sync.WaitGroup
+	shutdownHooks []func(game *Game)
+	shutdownOnce sync.Once
+	ctxMU sync.Mutex
+
+	commands []func()
+	ticking int
+	commandsMU sync.Mutex
+
+	objects map[string][]*GameObject
+	collisionType map[string]uint8
+	objectInit []func(game *Game)
+	defaultObjects bool
+	objectsMU sync.Mutex
 }
 
 
-var GameObjectInit []func(game *Game) = []func(game *Game){}
+// defaultObjectInit is the list of object init callbacks added with 'InitObject'
+//
+// this is only a registration list, and a game only runs it if it calls 'game.UseDefaultObjects'
+var defaultObjectInit []func(game *Game)
+var defaultObjectInitMU sync.Mutex
 
+// InitObject adds an object init callback to the default list
+//
+// the default list only runs for games that call 'game.UseDefaultObjects'
+//
+// to add a callback to a single game, use 'game.InitObject'
 func InitObject(cb func(game *Game)){
-	GameObjectInit = append(GameObjectInit, cb)
+	defaultObjectInitMU.Lock()
+	defer defaultObjectInitMU.Unlock()
+
+	defaultObjectInit = append(defaultObjectInit, cb)
+}
+
+// UseDefaultObjects makes this game run the default object init callbacks (added with 'InitObject') in 'game.InitObjects'
+//
+// games that do not call this method (like a menu, or a game in a test) only run the callbacks added with 'game.InitObject'
+func (game *Game) UseDefaultObjects(){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	game.defaultObjects = true
+}
+
+// InitObject adds an object init callback that only runs for this game
+func (game *Game) InitObject(cb func(game *Game)){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	game.objectInit = append(game.objectInit, cb)
+}
+
+// InitObjects runs the default object init callbacks (if the game uses them), followed by the callbacks added to this game
+func (game *Game) InitObjects(){
+	list := []func(game *Game){}
+
+	game.objectsMU.Lock()
+	if game.defaultObjects {
+		defaultObjectInitMU.Lock()
+		list = append(list, defaultObjectInit...)
+		defaultObjectInitMU.Unlock()
+	}
+	list = append(list, game.objectInit...)
+	game.objectsMU.Unlock()
+
+	for _, cb := range list {
+		cb(game)
+	}
 }
 
 type GameObject struct{
+	game *Game
 	id string
 	objType string
 	name string
@@ -77,6 +208,50 @@ type GameObject struct{
 	VelX float32
 	VelY float32
 
+	// Rotation is the angle of an object in degrees (clockwise)
+	//
+	// the hitbox of a Box collision object will rotate with it
+	//
+	// note: only *canvas.Image objects can be drawn rotated (see 'NewRectImage' for solid shapes)
+	Rotation float32
+
+	// AngularVel is the rotation velocity of an object, and works the same way as VelX and VelY
+	AngularVel float32
+
+	// ScaleX multiplies the width of an object, without changing its Width
+	//
+	// default: 1 (a value of 0 is treated as 1)
+	ScaleX float32
+
+	// ScaleY multiplies the height of an object, without changing its Height
+	//
+	// default: 1 (a value of 0 is treated as 1)
+	ScaleY float32
+
+	rotImg image.Image
+	rotStep int
+	rotAspect float32
+	rotCache *rotationCache
+
+	// ZIndex sets the draw order of an object within its render layer
+	//
+	// objects with a higher ZIndex are drawn above objects with a lower ZIndex,
+	// and objects with the same ZIndex are drawn in the order they were added
+	//
+	// default: 0
+	ZIndex int
+
+	// Layer is the name of the render layer this object is drawn in
+	//
+	// changing this value will move the object to a different layer on the next draw
+	//
+	// default: the objType of the object
+	Layer string
+
+	zIndex int
+	layer string
+	order uint64
+
 	// OnBorderX returns -1 or 1 if this object if touching a border in the x axis
 	//
 	// it will also return -3 or 3 if it is past the border and fully hidden
@@ -108,6 +283,11 @@ type GameObject struct{
 	// Store is a basic map for storing extra data attached to an object if needed
 	Store map[string]any
 
+	// IgnorePause allows an object to keep updating while the game is paused
+	//
+	// example: menus and pause screen animations
+	IgnorePause bool
+
 	// PreferredFPS is an optional FPS preference for detection updates for an object
 	//
 	// example: border detection
@@ -138,24 +318,44 @@ type GameObject struct{
 	//
 	// example: updating entity stats (seperating this from the player can prevent input lag)
 	UpdateSlow func(game *Game, thread *ThreadInfo)
+
+	// Hidden hides an object and all of its children
+	Hidden bool
+
+	updates [4][]*objectUpdate
+	tags map[string]bool
+
+	parent *GameObject
+	children []*GameObject
+	hidden bool
+	removed bool
+	onRemove []func(game *Game, object *GameObject)
 }
 
-type Direction struct {
-	dist float32
-	dirX float32
-	dirY float32
+type objectUpdate struct {
+	cb func(game *Game, thread *ThreadInfo)
+	removed bool
 }
 
-var gameObjects map[string][]*GameObject = map[string][]*GameObject{}
-var gameObjectsMU sync.Mutex
+type Direction struct {
+	// Dist is the distance between the 2 objects
+	Dist float32
+
+	// DirX is the x part of the normalized direction (between -1 and 1)
+	DirX float32
 
-var gameColissionType map[string]uint8 = map[string]uint8{}
+	// DirY is the y part of the normalized direction (between -1 and 1)
+	DirY float32
+}
 
 // game methods
 
 // Add adds a new object to the game
+//
+// objects added during a game loop are added after the current tick has finished
 func (game *Game) Add(objType string, name string, x, y, width, height float32, cb func(game *Game) fyne.CanvasObject) *GameObject {
 	object := GameObject{
+		game: game,
 		id: string(goutil.Crypt.RandBytes(64)),
 		objType: objType,
 		name: name,
@@ -165,49 +365,95 @@ func (game *Game) Add(objType string, name string, x, y, width, height float32,
 		Y: y,
 		Width: width,
 		Height: height,
+
+		Layer: objType,
+		layer: objType,
+	}
+
+	game.command(func() {
+		game.addObject(&object)
+	})
+
+	return &object
+}
+
+// addObject adds an object to the list of objects and its render layer
+func (game *Game) addObject(object *GameObject){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	game.objectOrder++
+	object.order = game.objectOrder
+
+	if game.objects == nil {
+		game.objects = map[string][]*GameObject{}
 	}
 
-	gameObjectsMU.Lock()
-	if _, ok := gameObjects[objType]; !ok {
-		gameObjects[objType] = []*GameObject{}
+	objType := object.objType
+	if _, ok := game.objects[objType]; !ok {
+		game.objects[objType] = []*GameObject{}
 	}
-	gameObjects[objType] = append(gameObjects[objType], &object)
+	game.objects[objType] = append(game.objects[objType], object)
 	if _, ok := game.CanvasList[objType]; ok {
 		game.CanvasList[objType].Add(object.Object)
 		game.CanvasList[objType].Refresh()
-	}
-	gameObjectsMU.Unlock()
 
-	return &object
+		// new objects are added to the top, so the layer needs sorting if any objects are above the default ZIndex
+		if game.layerZIndex[objType] {
+			game.sortLayerLater(objType)
+		}
+	}
 }
 
 // RemoveType clears all objects of the same type
+//
+// objects removed during a game loop are marked as removed right away, and are taken out of the game after the current tick has finished
 func (game *Game) RemoveType(objType string){
-	gameObjectsMU.Lock()
-	defer gameObjectsMU.Unlock()
-
-	if _, ok := gameObjects[objType]; !ok {
-		return
+	game.objectsMU.Lock()
+	for _, object := range game.objects[objType] {
+		object.markRemoved()
 	}
+	game.objectsMU.Unlock()
+
+	game.command(func() {
+		game.objectsMU.Lock()
+
+		if _, ok := game.objects[objType]; !ok {
+			game.objectsMU.Unlock()
+			return
+		}
 
-	gameObjects[objType] = []*GameObject{}
-	game.CanvasList[objType].RemoveAll()
-	game.CanvasList[objType].Refresh()
+		hooks := []func(){}
+		for _, object := range append([]*GameObject{}, game.objects[objType]...) {
+			if object.parent != nil {
+				object.parent.removeChild(object)
+				object.parent = nil
+			}
+			hooks = append(hooks, object.remove(game)...)
+		}
+
+		game.objects[objType] = []*GameObject{}
+		game.objectsMU.Unlock()
+
+		for _, cb := range hooks {
+			cb()
+		}
+	})
 }
 
 // Get returns a list of objects by type and name
 func (game *Game) Get(objType string, name string) []*GameObject {
-	gameObjectsMU.Lock()
-	defer gameObjectsMU.Unlock()
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
 
-	if _, ok := gameObjects[objType]; !ok {
+	if _, ok := game.objects[objType]; !ok {
 		return []*GameObject{}
 	}
 
 	list := []*GameObject{}
 
-	for _, object := range gameObjects[objType] {
-		if object.name == name {
+	for _, object := range game.objects[objType] {
+		if object.name == name && !object.removed {
 			list = append(list, object)
 		}
 	}
@@ -216,28 +462,37 @@ func (game *Game) Get(objType string, name string) []*GameObject {
 }
 
 // GetType returns a list of objects by type
+//
+// the list is a copy, so it is safe to keep even if objects are added or removed
 func (game *Game) GetType(objType string) []*GameObject {
-	gameObjectsMU.Lock()
-	defer gameObjectsMU.Unlock()
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
 
-	if _, ok := gameObjects[objType]; !ok {
+	if _, ok := game.objects[objType]; !ok {
 		return []*GameObject{}
 	}
 
-	return gameObjects[objType]
+	list := []*GameObject{}
+	for _, object := range game.objects[objType] {
+		if !object.removed {
+			list = append(list, object)
+		}
+	}
+
+	return list
 }
 
 // GetID returns an object by its ID
 func (game *Game) GetID(objType string, id string) *GameObject {
-	gameObjectsMU.Lock()
-	defer gameObjectsMU.Unlock()
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
 
-	if _, ok := gameObjects[objType]; !ok {
+	if _, ok := game.objects[objType]; !ok {
 		return nil
 	}
 
-	for _, object := range gameObjects[objType] {
-		if object.id == id {
+	for _, object := range game.objects[objType] {
+		if object.id == id && !object.removed {
 			return object
 		}
 	}
@@ -245,11 +500,22 @@ func (game *Game) GetID(objType string, id string) *GameObject {
 	return nil
 }
 
+// eachObject runs a callback for each object, in the order of the render layers
+//
+// objects removed during the loop are skipped
 func (game *Game) eachObject(cb func(object *GameObject)){
-	for i := 0; i < len(game.CanvasListKeys); i++ {
-		for _, object := range gameObjects[game.CanvasListKeys[i]] {
-			cb(object)
+	game.objectsMU.Lock()
+	list := []*GameObject{}
+	for _, objType := range game.CanvasListKeys {
+		list = append(list, game.objects[objType]...)
+	}
+	game.objectsMU.Unlock()
+
+	for _, object := range list {
+		if object.IsRemoved() {
+			continue
 		}
+		cb(object)
 	}
 }
 
@@ -263,23 +529,140 @@ func (game *Game) SetTypeCollision(objType string, typeCollisionMethod uint8){
 		typeCollisionMethod = 0
 	}
 
-	gameObjectsMU.Lock()
-	gameColissionType[objType] = typeCollisionMethod
-	gameObjectsMU.Unlock()
+	game.objectsMU.Lock()
+	if game.collisionType == nil {
+		game.collisionType = map[string]uint8{}
+	}
+	game.collisionType[objType] = typeCollisionMethod
+	game.objectsMU.Unlock()
 }
 
 
 // object methods
 
 // Remove removes this object from the game
+//
+// any children attached to this object will also be removed
+//
+// objects removed during a game loop are marked as removed right away, and are taken out of the game after the current tick has finished
 func (object *GameObject) Remove(game *Game, thread *ThreadInfo){
-	gameObjectsMU.Lock()
-	defer gameObjectsMU.Unlock()
+	game.objectsMU.Lock()
+	object.markRemoved()
+	game.objectsMU.Unlock()
+
+	game.command(func() {
+		game.objectsMU.Lock()
+
+		if object.parent != nil {
+			object.parent.removeChild(object)
+			object.parent = nil
+		}
 
-	game.Canvas.Remove(object.Object)
-	for i, obj := range gameObjects[object.objType] {
+		hooks := object.remove(game)
+		game.objectsMU.Unlock()
+
+		for _, cb := range hooks {
+			cb()
+		}
+	})
+}
+
+// OnRemove adds a callback that runs after an object (or one of its parents) has been removed from the game
+//
+// this can be used to free anything that belongs to the object
+//
+// the callbacks only run once, in the order they were added
+func (object *GameObject) OnRemove(cb func(game *Game, object *GameObject)){
+	object.MU.Lock()
+	defer object.MU.Unlock()
+
+	object.onRemove = append(object.onRemove, cb)
+}
+
+// remove removes an object and its children from the game
+//
+// objectsMU should be locked before calling this method
+//
+// returns the OnRemove callbacks of the removed objects, which should be run after objectsMU is unlocked
+func (object *GameObject) remove(game *Game) []func() {
+	hooks := []func(){}
+	for _, child := range object.children {
+		child.parent = nil
+		hooks = append(hooks, child.remove(game)...)
+	}
+	object.children = nil
+
+	object.MU.Lock()
+	for _, cb := range object.onRemove {
+		cb := cb
+		hooks = append(hooks, func(){
+			cb(game, object)
+		})
+	}
+	object.onRemove = nil
+	object.MU.Unlock()
+
+	if box, ok := game.CanvasList[object.layer]; ok {
+		box.Remove(object.Object)
+	}
+
+	game.profileForget(object)
+
+	for i, obj := range game.objects[object.objType] {
 		if obj.id == object.id {
-			gameObjects[object.objType] = append(gameObjects[object.objType][:i], gameObjects[object.objType][i+1:]...)
+			game.objects[object.objType] = append(game.objects[object.objType][:i], game.objects[object.objType][i+1:]...)
+			break
+		}
+	}
+
+	return hooks
+}
+
+// AddUpdate adds an extra callback to an object, which runs on a game loop after the objects main callback for that loop
+//
+// this allows helpers (like pathfinding) to attach to an object without replacing its Update methods
+//
+// loop: the game loop to run on (from the Loop enum)
+//
+// returns a function that removes the callback
+func (object *GameObject) AddUpdate(loop uint8, cb func(game *Game, thread *ThreadInfo)) func() {
+	if int(loop) >= len(object.updates) {
+		loop = Loop.Update
+	}
+
+	update := objectUpdate{cb: cb}
+
+	object.MU.Lock()
+	object.updates[loop] = append(object.updates[loop], &update)
+	object.MU.Unlock()
+
+	return func(){
+		object.MU.Lock()
+		defer object.MU.Unlock()
+
+		update.removed = true
+		for i, u := range object.updates[loop] {
+			if u == &update {
+				object.updates[loop] = append(object.updates[loop][:i:i], object.updates[loop][i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// runUpdates runs the extra callbacks added to an object with 'AddUpdate'
+func (object *GameObject) runUpdates(loop uint8, game *Game, thread *ThreadInfo){
+	object.MU.Lock()
+	updates := object.updates[loop]
+	object.MU.Unlock()
+
+	for _, update := range updates {
+		object.MU.Lock()
+		removed := update.removed
+		object.MU.Unlock()
+
+		if !removed {
+			update.cb(game, thread)
 		}
 	}
 }
@@ -288,26 +671,34 @@ func (object *GameObject) Remove(game *Game, thread *ThreadInfo){
 //
 // this method will be called by the different update methods depending on an objects PreferredFPS
 func (object *GameObject) handleBorder(game *Game, thread *ThreadInfo){
+	// the position of a child is relative to its parent, so the parent handles the border
+	if object.Parent() != nil {
+		return
+	}
+
+	// use the size of the box that fits around the scaled and rotated object
+	width, height := object.hitbox().extents()
+
 	{ // check if object in on or past border
-		if object.X + object.Width < -game.Size.Width {
+		if object.X + width < -game.Size.Width {
 			object.OnBorderX = -3
-		} else if object.X - object.Width > game.Size.Width {
+		} else if object.X - width > game.Size.Width {
 			object.OnBorderX = 3
-		}else if object.X - object.Width <= -game.Size.Width {
+		}else if object.X - width <= -game.Size.Width {
 			object.OnBorderX = -1
-		}else if object.X + object.Width >= game.Size.Width {
+		}else if object.X + width >= game.Size.Width {
 			object.OnBorderX = 1
 		}else{
 			object.OnBorderX = 0
 		}
 	
-		if object.Y + object.Height < -game.Size.Height {
+		if object.Y + height < -game.Size.Height {
 			object.OnBorderY = -3
-		} else if object.Y - object.Height > game.Size.Height {
+		} else if object.Y - height > game.Size.Height {
 			object.OnBorderY = 3
-		}else if object.Y - object.Height <= -game.Size.Height {
+		}else if object.Y - height <= -game.Size.Height {
 			object.OnBorderY = -1
-		}else if object.Y + object.Height >= game.Size.Height {
+		}else if object.Y + height >= game.Size.Height {
 			object.OnBorderY = 1
 		}else{
 			object.OnBorderY = 0
@@ -329,29 +720,29 @@ func (object *GameObject) handleBorder(game *Game, thread *ThreadInfo){
 	// handle object border method
 	switch object.BorderMethod {
 	case BorderMethod.PushLimit:
-		if object.X - object.Width < -game.Size.Width {
-			object.X = -game.Size.Width + object.Width
-		}else if object.X + object.Width > game.Size.Width {
-			object.X = game.Size.Width - object.Width
+		if object.X - width < -game.Size.Width {
+			object.X = -game.Size.Width + width
+		}else if object.X + width > game.Size.Width {
+			object.X = game.Size.Width - width
 		}
 
-		if object.Y - object.Height < -game.Size.Height {
-			object.Y = -game.Size.Height + object.Height
-		}else if object.Y + object.Height > game.Size.Height {
-			object.Y = game.Size.Height - object.Height
+		if object.Y - height < -game.Size.Height {
+			object.Y = -game.Size.Height + height
+		}else if object.Y + height > game.Size.Height {
+			object.Y = game.Size.Height - height
 		}
 
 	case BorderMethod.PushHide:
-		if object.X + object.Width < -game.Size.Width {
-			object.X = -game.Size.Width - object.Width - 0.25
-		}else if object.X - object.Width > game.Size.Width {
-			object.X = game.Size.Width + object.Width + 0.25
+		if object.X + width < -game.Size.Width {
+			object.X = -game.Size.Width - width - 0.25
+		}else if object.X - width > game.Size.Width {
+			object.X = game.Size.Width + width + 0.25
 		}
 
-		if object.Y + object.Height < -game.Size.Height {
-			object.Y = -game.Size.Height - object.Height - 0.25
-		}else if object.Y - object.Height > game.Size.Height {
-			object.Y = game.Size.Height + object.Height + 0.25
+		if object.Y + height < -game.Size.Height {
+			object.Y = -game.Size.Height - height - 0.25
+		}else if object.Y - height > game.Size.Height {
+			object.Y = game.Size.Height + height + 0.25
 		}
 
 	case BorderMethod.Bounce:
@@ -364,15 +755,15 @@ func (object *GameObject) handleBorder(game *Game, thread *ThreadInfo){
 
 	case BorderMethod.Teleport:
 		if object.OnBorderX <= -4 {
-			object.X = game.Size.Width + object.Width
+			object.X = game.Size.Width + width
 		}else if object.OnBorderX >= 4 {
-			object.X = -game.Size.Width - object.Width
+			object.X = -game.Size.Width - width
 		}
 
 		if object.OnBorderY <= -4 {
-			object.Y = game.Size.Height + object.Height
+			object.Y = game.Size.Height + height
 		}else if object.OnBorderY >= 4 {
-			object.Y = -game.Size.Height - object.Height
+			object.Y = -game.Size.Height - height
 		}
 
 	case BorderMethod.RemoveObject:
@@ -385,20 +776,31 @@ func (object *GameObject) handleBorder(game *Game, thread *ThreadInfo){
 
 // GetDistance calculates the distance between 2 objects
 func (obj1 *GameObject) GetDistance(obj2 *GameObject) float32 {
-	diffX := obj1.X - obj2.X
-	diffY := obj1.Y - obj2.Y
+	x1, y1 := obj1.WorldPos()
+	x2, y2 := obj2.WorldPos()
+	diffX := x1 - x2
+	diffY := y1 - y2
 	return float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 }
 
 // GetDirection is similar to the 'GetDistance' method,
 // but this method aso returns the direction of an object and the difference between the x and y distance
 //
-// example use: velX += Direction.dirX * speed; velY += Direction.dirY * speed (to move twards an object)
+// the direction points from obj2 to obj1
+//
+// example use: obj2.VelX += Direction.DirX * speed; obj2.VelY += Direction.DirY * speed (to move obj2 twards obj1)
 func (obj1 *GameObject) GetDirection(obj2 *GameObject) Direction {
-	diffX := obj1.X - obj2.X
-	diffY := obj1.Y - obj2.Y
+	x1, y1 := obj1.WorldPos()
+	x2, y2 := obj2.WorldPos()
+	diffX := x1 - x2
+	diffY := y1 - y2
 	dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 
+	// prevent dividing by 0 if both objects are in the same position
+	if dist == 0 {
+		return Direction{}
+	}
+
 	dirX := (1/dist * diffX)
 	dirY := (1/dist * diffY)
 
@@ -422,26 +824,34 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 		return false
 	}
 
+	// objects waiting to be removed can no longer collide
+	if obj1.IsRemoved() || obj2.IsRemoved() {
+		return false
+	}
+
+	b1 := obj1.hitbox()
+	b2 := obj2.hitbox()
+
 	if obj1.CollisionMethod == CollisionMethod.Box && obj2.CollisionMethod == CollisionMethod.Box {
-		if (obj1.X + obj1.Width > obj2.X - obj2.Width && obj1.X - obj1.Width < obj2.X + obj2.Width) && 
-		(obj1.Y + obj1.Height > obj2.Y - obj2.Height && obj1.Y - obj1.Height < obj2.Y + obj2.Height) {
+		if isBoxColliding(b1, b2) {
 			return true
 		}
 	}else if obj1.CollisionMethod == CollisionMethod.Radius && obj2.CollisionMethod == CollisionMethod.Radius {
 		dist := obj1.GetDistance(obj2)
-		return dist <= float32(math.Sqrt(math.Pow(float64(obj1.Width + obj2.Width), 2) + math.Pow(float64(obj1.Height + obj2.Height), 2))) / (math.Pi / 2.25)
+		return dist <= float32(math.Sqrt(math.Pow(float64(b1.Width + b2.Width), 2) + math.Pow(float64(b1.Height + b2.Height), 2))) / (math.Pi / 2.25)
 	}else if obj1.CollisionMethod == CollisionMethod.Box && obj2.CollisionMethod == CollisionMethod.Radius {
-		size := float32(math.Sqrt(math.Pow(float64(obj2.Width), 2) + math.Pow(float64(obj2.Height), 2))) / (math.Pi / 2.25)
+		size := float32(math.Sqrt(math.Pow(float64(b2.Width), 2) + math.Pow(float64(b2.Height), 2))) / (math.Pi / 2.25)
+		b1, b2 = toBoxSpace(b1, b2)
 
 		// skip math loop if object is too far away
-		if dist := obj1.GetDistance(obj2); dist > size + (obj1.Width * 2) && dist > size + (obj1.Height * 2) {
+		if dist := obj1.GetDistance(obj2); dist > size + (b1.Width * 2) && dist > size + (b1.Height * 2) {
 			return false
 		}
 
-		for w := -obj1.Width; w <= obj1.Width; w += obj2.Width / math.Pi {
-			for h := -obj1.Height; h <= obj1.Height; h += obj2.Height / math.Pi {
-				diffX := (obj1.X + w) - obj2.X
-				diffY := (obj1.Y + h) - obj2.Y
+		for w := -b1.Width; w <= b1.Width; w += b2.Width / math.Pi {
+			for h := -b1.Height; h <= b1.Height; h += b2.Height / math.Pi {
+				diffX := (b1.X + w) - b2.X
+				diffY := (b1.Y + h) - b2.Y
 				dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 
 				if dist <= size {
@@ -450,8 +860,8 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 			}
 
 			// cover final height check
-			diffX := (obj1.X + w) - obj2.X
-			diffY := (obj1.Y + obj1.Height) - obj2.Y
+			diffX := (b1.X + w) - b2.X
+			diffY := (b1.Y + b1.Height) - b2.Y
 			dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 			if dist <= size {
 				return true
@@ -459,9 +869,9 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 		}
 
 		// cover final width checks
-		for h := -obj1.Height; h <= obj1.Height; h += obj2.Height / math.Pi {
-			diffX := (obj1.X + obj1.Width) - obj2.X
-			diffY := (obj1.Y + h) - obj2.Y
+		for h := -b1.Height; h <= b1.Height; h += b2.Height / math.Pi {
+			diffX := (b1.X + b1.Width) - b2.X
+			diffY := (b1.Y + h) - b2.Y
 			dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 			if dist <= size {
 				return true
@@ -469,8 +879,8 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 		}
 
 		// cover final width and height check
-		diffX := (obj1.X + obj1.Width) - obj2.X
-		diffY := (obj1.Y + obj1.Height) - obj2.Y
+		diffX := (b1.X + b1.Width) - b2.X
+		diffY := (b1.Y + b1.Height) - b2.Y
 		dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 		if dist <= size {
 			return true
@@ -478,17 +888,18 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 
 		return false
 	}else if obj1.CollisionMethod == CollisionMethod.Radius && obj2.CollisionMethod == CollisionMethod.Box {
-		size := float32(math.Sqrt(math.Pow(float64(obj1.Width), 2) + math.Pow(float64(obj1.Height), 2))) / (math.Pi / 2.25)
+		size := float32(math.Sqrt(math.Pow(float64(b1.Width), 2) + math.Pow(float64(b1.Height), 2))) / (math.Pi / 2.25)
+		b2, b1 = toBoxSpace(b2, b1)
 
 		// skip math loop if object is too far away
-		if dist := obj2.GetDistance(obj1); dist > size + (obj2.Width * 2) && dist > size + (obj2.Height * 2) {
+		if dist := obj2.GetDistance(obj1); dist > size + (b2.Width * 2) && dist > size + (b2.Height * 2) {
 			return false
 		}
 
-		for w := -obj2.Width; w <= obj2.Width; w += obj1.Width / math.Pi {
-			for h := -obj2.Height; h <= obj2.Height; h += obj1.Height / math.Pi {
-				diffX := (obj2.X + w) - obj1.X
-				diffY := (obj2.Y + h) - obj1.Y
+		for w := -b2.Width; w <= b2.Width; w += b1.Width / math.Pi {
+			for h := -b2.Height; h <= b2.Height; h += b1.Height / math.Pi {
+				diffX := (b2.X + w) - b1.X
+				diffY := (b2.Y + h) - b1.Y
 				dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 
 				if dist <= size {
@@ -497,8 +908,8 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 			}
 
 			// cover final height check
-			diffX := (obj2.X + w) - obj1.X
-			diffY := (obj2.Y + obj2.Height) - obj1.Y
+			diffX := (b2.X + w) - b1.X
+			diffY := (b2.Y + b2.Height) - b1.Y
 			dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 			if dist <= size {
 				return true
@@ -506,9 +917,9 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 		}
 
 		// cover final width checks
-		for h := -obj2.Height; h <= obj2.Height; h += obj1.Height / math.Pi {
-			diffX := (obj2.X + obj2.Width) - obj1.X
-			diffY := (obj2.Y + h) - obj1.Y
+		for h := -b2.Height; h <= b2.Height; h += b1.Height / math.Pi {
+			diffX := (b2.X + b2.Width) - b1.X
+			diffY := (b2.Y + h) - b1.Y
 			dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 			if dist <= size {
 				return true
@@ -516,8 +927,8 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 		}
 
 		// cover final width and height check
-		diffX := (obj2.X + obj2.Width) - obj1.X
-		diffY := (obj2.Y + obj2.Height) - obj1.Y
+		diffX := (b2.X + b2.Width) - b1.X
+		diffY := (b2.Y + b2.Height) - b1.Y
 		dist := float32(math.Sqrt(math.Pow(float64(diffX), 2) + math.Pow(float64(diffY), 2)))
 		if dist <= size {
 			return true
@@ -531,10 +942,7 @@ func (obj1 *GameObject) IsColideing(obj2 *GameObject) bool {
 
 // IsColideingAny returns a list of colliding objects
 func (object *GameObject) IsColideingAny() []*GameObject {
-	colType := uint8(0)
-	if v, ok := gameColissionType[object.objType]; ok {
-		colType = v
-	}
+	colType := object.game.typeCollision(object.objType)
 
 	list := []*GameObject{}
 	if colType == TypeCollisionMethod.Ghost {
@@ -542,7 +950,7 @@ func (object *GameObject) IsColideingAny() []*GameObject {
 	}
 
 	if colType == TypeCollisionMethod.Self {
-		if objList, ok := gameObjects[object.objType]; ok {
+		if objList, ok := object.game.typeObjects(object.objType); ok {
 			for _, obj := range objList {
 				if object.IsColideing(obj) {
 					list = append(list, obj)
@@ -553,11 +961,8 @@ func (object *GameObject) IsColideingAny() []*GameObject {
 		return list
 	}
 
-	for key, objList := range gameObjects {
-		cType := uint8(0)
-		if v, ok := gameColissionType[key]; ok {
-			cType = v
-		}
+	for key, objList := range object.game.allObjects() {
+		cType := object.game.typeCollision(key)
 
 		if cType == TypeCollisionMethod.Ghost ||
 		(object.objType != key && cType == TypeCollisionMethod.Self) ||
@@ -577,10 +982,7 @@ func (object *GameObject) IsColideingAny() []*GameObject {
 
 // IsColideingAny returns a list of colliding objects of a specific type
 func (object *GameObject) IsColideingType(objType string) []*GameObject {
-	colType := uint8(0)
-	if v, ok := gameColissionType[object.objType]; ok {
-		colType = v
-	}
+	colType := object.game.typeCollision(object.objType)
 
 	list := []*GameObject{}
 	if colType == TypeCollisionMethod.Ghost ||
@@ -589,11 +991,8 @@ func (object *GameObject) IsColideingType(objType string) []*GameObject {
 		return list
 	}
 
-	if objList, ok := gameObjects[objType]; ok {
-		cType := uint8(0)
-		if v, ok := gameColissionType[objType]; ok {
-			cType = v
-		}
+	if objList, ok := object.game.typeObjects(objType); ok {
+		cType := object.game.typeCollision(objType)
 
 		if cType == TypeCollisionMethod.Ghost ||
 		(object.objType != objType && cType == TypeCollisionMethod.Self) {
@@ -612,10 +1011,7 @@ func (object *GameObject) IsColideingType(objType string) []*GameObject {
 
 // IsColideingAny returns a list of colliding objects of a specific type and name
 func (object *GameObject) IsColideingName(objType string, name string) []*GameObject {
-	colType := uint8(0)
-	if v, ok := gameColissionType[object.objType]; ok {
-		colType = v
-	}
+	colType := object.game.typeCollision(object.objType)
 
 	list := []*GameObject{}
 	if colType == TypeCollisionMethod.Ghost ||
@@ -624,11 +1020,8 @@ func (object *GameObject) IsColideingName(objType string, name string) []*GameOb
 		return list
 	}
 
-	if objList, ok := gameObjects[objType]; ok {
-		cType := uint8(0)
-		if v, ok := gameColissionType[objType]; ok {
-			cType = v
-		}
+	if objList, ok := object.game.typeObjects(objType); ok {
+		cType := object.game.typeCollision(objType)
 
 		if cType == TypeCollisionMethod.Ghost ||
 		(object.objType != objType && cType == TypeCollisionMethod.Self) {
@@ -646,20 +1039,66 @@ func (object *GameObject) IsColideingName(objType string, name string) []*GameOb
 }
 
 
+// typeObjects returns a copy of the list of objects of a type
+func (game *Game) typeObjects(objType string) ([]*GameObject, bool) {
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	objList, ok := game.objects[objType]
+	return append([]*GameObject{}, objList...), ok
+}
+
+// typeCollision returns the collision type of an object type
+func (game *Game) typeCollision(objType string) uint8 {
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	return game.collisionType[objType]
+}
+
+// allObjects returns a copy of the lists of objects of every type
+func (game *Game) allObjects() map[string][]*GameObject {
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	list := make(map[string][]*GameObject, len(game.objects))
+	for objType, objList := range game.objects {
+		list[objType] = append([]*GameObject{}, objList...)
+	}
+	return list
+}
+
+
 // basic methods
 
 // Update should run on a GameLoop thread
 //
 // recommended: 60 fps
 func Update(game *Game, thread *ThreadInfo){
+	defer game.profileLoop(Loop.Update, thread)()
+	game.beginTick()
+	defer game.endTick()
+
+	game.setThreadTime(Loop.Update, thread)
+	game.runTimers(Loop.Update, thread)
+	game.dispatchEvents(Loop.Update, thread)
+
 	game.eachObject(func(object *GameObject) {
+		thread := object.threadTime(thread)
+		if thread == nil {
+			return
+		}
+
 		if object.PreferredFPS >= 60 && object.PreferredFPS < 120 { 
 			object.handleBorder(game, thread)
 		}
 
+		start := game.profileObjectStart()
 		if object.Update != nil {
 			object.Update(game, thread)
 		}
+		object.runUpdates(Loop.Update, game, thread)
+		game.profileObject(Loop.Update, object, start)
 	})
 }
 
@@ -667,76 +1106,131 @@ func Update(game *Game, thread *ThreadInfo){
 //
 // recommended: 120 fps
 func Draw(game *Game, thread *ThreadInfo){
+	defer game.profileLoop(Loop.Draw, thread)()
+	game.beginTick()
+	defer game.endTick()
+
+	game.setThreadTime(Loop.Draw, thread)
+	game.addTime(thread.Delta)
+	game.runTimers(Loop.Draw, thread)
+	game.dispatchEvents(Loop.Draw, thread)
+	game.runTweens(thread)
+
 	game.eachObject(func(object *GameObject) {
-		if object.PreferredFPS == 0 || object.PreferredFPS > 120 { 
-			object.handleBorder(game, thread)
+		if thread := object.threadTime(thread); thread != nil {
+			object.move(game, thread)
+
+			start := game.profileObjectStart()
+			if object.Draw != nil {
+				object.Draw(game, thread)
+			}
+			object.runUpdates(Loop.Draw, game, thread)
+			game.profileObject(Loop.Draw, object, start)
 		}
 
-		// handle object border method
-		switch object.BorderMethod {
-		case BorderMethod.Ignore:
-			object.X += object.VelX / 10 * thread.SpeedDelta
-			object.Y += object.VelY / 10 * thread.SpeedDelta
+		object.handleLayer(game)
+		object.handleHidden()
 
-		case BorderMethod.Limit:
-			if object.OnBorderX == 0 || object.OnBorderX % 2 != 0 {
-				object.X += object.VelX / 10 * thread.SpeedDelta
-			}
-			if object.OnBorderY == 0 || object.OnBorderY % 2 != 0 {
-				object.Y += object.VelY / 10 * thread.SpeedDelta
-			}
+		object.render(game)
+	})
 
-		case BorderMethod.Hide:
-			if object.OnBorderX >= -3 && object.OnBorderX <= 3 {
-				object.X += object.VelX / 10 * thread.SpeedDelta
-			}
-			if object.OnBorderY >= -3 && object.OnBorderY <= 3 {
-				object.Y += object.VelY / 10 * thread.SpeedDelta
-			}
+	game.sortLayers()
+	game.drawDebug()
 
-		case BorderMethod.PushLimit:
-			if object.OnBorderX == 0 || object.OnBorderX % 2 != 0 {
-				object.X += object.VelX / 10 * thread.SpeedDelta
-			}
-			if object.OnBorderY == 0 || object.OnBorderY % 2 != 0 {
-				object.Y += object.VelY / 10 * thread.SpeedDelta
-			}
+	if game.HUD != nil {
+		game.HUD.update()
+	}
+}
 
-		case BorderMethod.PushHide:
-			if object.OnBorderX >= -3 && object.OnBorderX <= 3 {
-				object.X += object.VelX / 10 * thread.SpeedDelta
-			}
-			if object.OnBorderY >= -3 && object.OnBorderY <= 3 {
-				object.Y += object.VelY / 10 * thread.SpeedDelta
-			}
+// move handles border detection and applies the velocity of an object
+//
+// this method will be called by the draw method
+func (object *GameObject) move(game *Game, thread *ThreadInfo){
+	if object.PreferredFPS == 0 || object.PreferredFPS > 120 { 
+		object.handleBorder(game, thread)
+	}
+
+	speed := thread.SpeedDelta * thread.TimeScale
 
-		default:
-			object.X += object.VelX / 10 * thread.SpeedDelta
-			object.Y += object.VelY / 10 * thread.SpeedDelta
+	if object.AngularVel != 0 {
+		object.Rotation = float32(math.Mod(float64(object.Rotation + object.AngularVel / 10 * speed), 360))
+		if object.Rotation < 0 {
+			object.Rotation += 360
 		}
+	}
+
+	// handle object border method
+	switch object.BorderMethod {
+	case BorderMethod.Ignore:
+		object.X += object.VelX / 10 * speed
+		object.Y += object.VelY / 10 * speed
 
-		if object.Draw != nil {
-			object.Draw(game, thread)
+	case BorderMethod.Limit:
+		if object.OnBorderX == 0 || object.OnBorderX % 2 != 0 {
+			object.X += object.VelX / 10 * speed
+		}
+		if object.OnBorderY == 0 || object.OnBorderY % 2 != 0 {
+			object.Y += object.VelY / 10 * speed
 		}
 
-		object.Object.Move(fyne.NewPos(((object.X - object.Width) * game.Size.Scale) + (game.Size.RealWidth/2), ((object.Y - object.Height) * game.Size.Scale) + (game.Size.RealHeight/2)))
-		object.Object.Resize(fyne.NewSize((object.Width * 2) * game.Size.Scale, (object.Height * 2) * game.Size.Scale))
-		object.Object.Refresh()
-	})
+	case BorderMethod.Hide:
+		if object.OnBorderX >= -3 && object.OnBorderX <= 3 {
+			object.X += object.VelX / 10 * speed
+		}
+		if object.OnBorderY >= -3 && object.OnBorderY <= 3 {
+			object.Y += object.VelY / 10 * speed
+		}
+
+	case BorderMethod.PushLimit:
+		if object.OnBorderX == 0 || object.OnBorderX % 2 != 0 {
+			object.X += object.VelX / 10 * speed
+		}
+		if object.OnBorderY == 0 || object.OnBorderY % 2 != 0 {
+			object.Y += object.VelY / 10 * speed
+		}
+
+	case BorderMethod.PushHide:
+		if object.OnBorderX >= -3 && object.OnBorderX <= 3 {
+			object.X += object.VelX / 10 * speed
+		}
+		if object.OnBorderY >= -3 && object.OnBorderY <= 3 {
+			object.Y += object.VelY / 10 * speed
+		}
+
+	default:
+		object.X += object.VelX / 10 * speed
+		object.Y += object.VelY / 10 * speed
+	}
 }
 
 // UpdateBasic should run on a GameLoop thread
 //
 // recommended: 15 fps
 func UpdateBasic(game *Game, thread *ThreadInfo){
+	defer game.profileLoop(Loop.UpdateBasic, thread)()
+	game.beginTick()
+	defer game.endTick()
+
+	game.setThreadTime(Loop.UpdateBasic, thread)
+	game.runTimers(Loop.UpdateBasic, thread)
+	game.dispatchEvents(Loop.UpdateBasic, thread)
+
 	game.eachObject(func(object *GameObject) {
+		thread := object.threadTime(thread)
+		if thread == nil {
+			return
+		}
+
 		if object.PreferredFPS >= 15 && object.PreferredFPS < 30 { 
 			object.handleBorder(game, thread)
 		}
 
+		start := game.profileObjectStart()
 		if object.UpdateBasic != nil {
 			object.UpdateBasic(game, thread)
 		}
+		object.runUpdates(Loop.UpdateBasic, game, thread)
+		game.profileObject(Loop.UpdateBasic, object, start)
 	})
 }
 
@@ -744,13 +1238,29 @@ func UpdateBasic(game *Game, thread *ThreadInfo){
 //
 // recommended: 30 fps
 func UpdateSlow(game *Game, thread *ThreadInfo){
+	defer game.profileLoop(Loop.UpdateSlow, thread)()
+	game.beginTick()
+	defer game.endTick()
+
+	game.setThreadTime(Loop.UpdateSlow, thread)
+	game.runTimers(Loop.UpdateSlow, thread)
+	game.dispatchEvents(Loop.UpdateSlow, thread)
+
 	game.eachObject(func(object *GameObject) {
+		thread := object.threadTime(thread)
+		if thread == nil {
+			return
+		}
+
 		if object.PreferredFPS >= 30 && object.PreferredFPS < 60 { 
 			object.handleBorder(game, thread)
 		}
 
+		start := game.profileObjectStart()
 		if object.UpdateSlow != nil {
 			object.UpdateSlow(game, thread)
 		}
+		object.runUpdates(Loop.UpdateSlow, game, thread)
+		game.profileObject(Loop.UpdateSlow, object, start)
 	})
 }
@@ -0,0 +1,73 @@
+package gametest
+
+import (
+	"game/gamehandler"
+)
+
+// ReachesBorder runs ticks until an object touches or passes a border of the game
+//
+// returns the number of ticks that ran, and false if the object did not reach a border within maxTicks
+func (game *Game) ReachesBorder(object *gamehandler.GameObject, maxTicks int) (int, bool) {
+	return game.Until(maxTicks, func() bool {
+		return object.OnBorderX != 0 || object.OnBorderY != 0
+	})
+}
+
+// Collides runs ticks until 2 objects collide
+//
+// returns the number of ticks that ran, and false if the objects did not collide within maxTicks
+func (game *Game) Collides(obj1 *gamehandler.GameObject, obj2 *gamehandler.GameObject, maxTicks int) (int, bool) {
+	return game.Until(maxTicks, func() bool {
+		return obj1.IsColideing(obj2)
+	})
+}
+
+// AssertReachesBorder fails the test if an object does not reach a border of the game within maxTicks
+func (game *Game) AssertReachesBorder(object *gamehandler.GameObject, maxTicks int) int {
+	game.TB.Helper()
+
+	ticks, ok := game.ReachesBorder(object, maxTicks)
+	if !ok {
+		x, y := object.WorldPos()
+		game.TB.Errorf("%s %q did not reach a border within %d ticks (position: %g, %g)", object.Type(), object.Name(), maxTicks, x, y)
+	}
+	return ticks
+}
+
+// AssertCollides fails the test if 2 objects do not collide within maxTicks
+func (game *Game) AssertCollides(obj1 *gamehandler.GameObject, obj2 *gamehandler.GameObject, maxTicks int) int {
+	game.TB.Helper()
+
+	ticks, ok := game.Collides(obj1, obj2, maxTicks)
+	if !ok {
+		game.TB.Errorf("%s %q did not collide with %s %q within %d ticks (distance: %g)", obj1.Type(), obj1.Name(), obj2.Type(), obj2.Name(), maxTicks, obj1.GetDistance(obj2))
+	}
+	return ticks
+}
+
+// AssertNoCollision fails the test if 2 objects collide at any point during the next number of ticks
+func (game *Game) AssertNoCollision(obj1 *gamehandler.GameObject, obj2 *gamehandler.GameObject, ticks int){
+	game.TB.Helper()
+
+	if tick, ok := game.Collides(obj1, obj2, ticks); ok {
+		game.TB.Errorf("%s %q collided with %s %q after %d ticks", obj1.Type(), obj1.Name(), obj2.Type(), obj2.Name(), tick)
+	}
+}
+
+// AssertRemoved fails the test if an object has not been removed from the game
+func (game *Game) AssertRemoved(object *gamehandler.GameObject){
+	game.TB.Helper()
+
+	if !object.IsRemoved() {
+		game.TB.Errorf("%s %q was not removed", object.Type(), object.Name())
+	}
+}
+
+// AssertCount fails the test if the number of objects of a type is not the expected count
+func (game *Game) AssertCount(objType string, count int){
+	game.TB.Helper()
+
+	if n := len(game.GetType(objType)); n != count {
+		game.TB.Errorf("expected %d objects of type %s, found %d", count, objType, n)
+	}
+}
@@ -0,0 +1,181 @@
+// Package gametest runs a game without a window, so gameplay can be tested with 'go test'
+//
+// the game loops do not run on their own, and instead advance one tick at a time,
+// which makes every test run the same way no matter how fast the computer is
+package gametest
+
+import (
+	"game/gamehandler"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// TickRate is the number of ticks in one second of game time
+//
+// each tick runs the Draw loop, and the slower loops run on every few ticks to match their FPS
+const TickRate = 120
+
+// loops is the list of game loops, and how many ticks there are between each run
+var loops = []struct {
+	loop func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)
+	every uint64
+	fps uint16
+}{
+	{gamehandler.Update, 2, 60},
+	{gamehandler.UpdateSlow, 4, 30},
+	{gamehandler.UpdateBasic, 8, 15},
+	{gamehandler.Draw, 1, 120},
+}
+
+// Game is a headless game used for testing
+type Game struct {
+	*gamehandler.Game
+
+	// TB is the test the game belongs to, and is used to report failed assertions
+	TB testing.TB
+
+	// Ticks is the number of ticks that have run since the game was created
+	Ticks uint64
+
+	canvas *keyCanvas
+	frames [4]uint16
+}
+
+// New creates a new headless game
+//
+// objectTypes: the render layers to create, in the same way as ObjectTypes in config.yml (default: "object")
+//
+// the game is 720x480 pixels, and is shut down when the test finishes
+//
+// note: this replaces the current fyne app with a test app
+//
+// example: game := gametest.New(t, "object", "player")
+func New(tb testing.TB, objectTypes ...string) *Game {
+	tb.Helper()
+
+	if len(objectTypes) == 0 {
+		objectTypes = []string{"object"}
+	}
+
+	a := newTestApp()
+	w := a.NewWindow("Test")
+
+	canvasList := map[string]*fyne.Container{}
+	canvasListKeys := []string{}
+	canvasListArr := []fyne.CanvasObject{}
+	for _, objType := range objectTypes {
+		canvasList[objType] = container.NewWithoutLayout()
+		canvasListKeys = append(canvasListKeys, objType)
+		canvasListArr = append(canvasListArr, canvasList[objType])
+	}
+	canvasBox := container.NewWithoutLayout(canvasListArr...)
+
+	hud := gamehandler.NewHUD()
+	w.SetContent(container.NewMax(canvasBox, hud.Container))
+
+	game := Game{
+		Game: &gamehandler.Game{
+			Canvas: canvasBox,
+			CanvasList: canvasList,
+			CanvasListKeys: canvasListKeys,
+			Window: w,
+			HUD: hud,
+			MaxFPS: TickRate,
+		},
+		TB: tb,
+		canvas: w.Canvas().(*keyCanvas),
+	}
+
+	game.Resize(720, 480)
+
+	tb.Cleanup(func() {
+		game.Shutdown()
+		w.Close()
+	})
+
+	return &game
+}
+
+// Resize changes the size of the game in pixels
+func (game *Game) Resize(width, height float32){
+	game.Window.Resize(fyne.NewSize(width, height))
+
+	scale := width
+	if height < width {
+		scale = height
+	}
+	scale /= 100
+
+	game.MU.Lock()
+	game.Size = gamehandler.CanvasSize{
+		RealWidth: width,
+		RealHeight: height,
+		Scale: scale,
+
+		Width: width / scale / 2,
+		Height: height / scale / 2,
+	}
+	game.MU.Unlock()
+}
+
+// Step runs a number of ticks
+//
+// Draw runs on every tick, Update on every 2nd tick, UpdateSlow on every 4th tick, and UpdateBasic on every 8th tick
+func (game *Game) Step(ticks int){
+	for i := 0; i < ticks; i++ {
+		game.Ticks++
+
+		for l, loop := range loops {
+			if game.Ticks % loop.every != 0 {
+				continue
+			}
+
+			game.MU.Lock()
+			loop.loop(game.Game, &gamehandler.ThreadInfo{
+				FPS: loop.fps,
+				Frame: game.frames[l],
+				SpeedDelta: 1,
+				Delta: time.Second / time.Duration(loop.fps),
+			})
+			game.MU.Unlock()
+
+			game.frames[l]++
+			if game.frames[l] >= loop.fps {
+				game.frames[l] = 0
+			}
+		}
+	}
+}
+
+// Advance runs enough ticks to move the game forward by an amount of game time
+//
+// example: game.Advance(2 * time.Second)
+func (game *Game) Advance(d time.Duration){
+	game.Step(int(d * TickRate / time.Second))
+}
+
+// Until runs ticks until a condition returns true
+//
+// the condition is checked before the first tick, and after each tick
+//
+// returns the number of ticks that ran, and false if the condition was still false after maxTicks
+func (game *Game) Until(maxTicks int, cond func() bool) (int, bool) {
+	for i := 0; i <= maxTicks; i++ {
+		game.MU.Lock()
+		done := cond()
+		game.MU.Unlock()
+
+		if done {
+			return i, true
+		}
+
+		if i != maxTicks {
+			game.Step(1)
+		}
+	}
+
+	return maxTicks, false
+}
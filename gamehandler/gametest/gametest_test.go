@@ -0,0 +1,136 @@
+package gametest
+
+import (
+	"encoding/json"
+	"game/enum/BorderMethod"
+	"game/enum/CollisionMethod"
+	"game/gamehandler"
+	"image/color"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// newBox adds a box to a test game
+func newBox(game *Game, name string, x, y float32) *gamehandler.GameObject {
+	return game.Add("object", name, x, y, 4, 4, func(game *gamehandler.Game) fyne.CanvasObject {
+		return canvas.NewRectangle(color.White)
+	})
+}
+
+// newScene adds a player moving towards a wall, and a ball moving towards the right border
+func newScene(game *Game) (*gamehandler.GameObject, *gamehandler.GameObject, *gamehandler.GameObject) {
+	player := newBox(game, "player", -20, 0)
+	player.VelX = 2
+	player.CollisionMethod = CollisionMethod.Box
+
+	wall := newBox(game, "wall", 20, 0)
+	wall.CollisionMethod = CollisionMethod.Box
+
+	ball := newBox(game, "ball", 0, 20)
+	ball.VelX = 10
+	ball.BorderMethod = BorderMethod.Limit
+
+	return player, wall, ball
+}
+
+func TestReachesBorder(t *testing.T) {
+	game := New(t)
+	_, _, ball := newScene(game)
+
+	ticks := game.AssertReachesBorder(ball, 5 * TickRate)
+	if ticks == 0 {
+		t.Errorf("ball started on the border")
+	}
+	if ball.OnBorderX <= 0 {
+		t.Errorf("expected the ball to reach the right border, found %d", ball.OnBorderX)
+	}
+
+	// the ball should stay on the border with BorderMethod.Limit
+	x := ball.X
+	game.Advance(time.Second)
+	if ball.X != x {
+		t.Errorf("ball moved past the border from %g to %g", x, ball.X)
+	}
+}
+
+func TestCollides(t *testing.T) {
+	game := New(t)
+	player, wall, _ := newScene(game)
+
+	// the boxes start 40 units apart and touch when they are 8 units apart,
+	// and the player moves 0.2 units (VelX / 10) on each tick
+	ticks := game.AssertCollides(player, wall, 5 * TickRate)
+	if ticks < 155 || ticks > 165 {
+		t.Errorf("expected the player to collide after about 160 ticks, collided after %d", ticks)
+	}
+
+	if tick, ok := game.Collides(player, newBox(game, "far", 0, -40), TickRate); ok {
+		t.Errorf("player collided with an object it was not moving towards after %d ticks", tick)
+	}
+}
+
+func TestRemoved(t *testing.T) {
+	game := New(t)
+	newScene(game)
+
+	ball := newBox(game, "removed", 0, -20)
+	ball.VelY = -10
+	ball.BorderMethod = BorderMethod.RemoveObject
+
+	game.AssertCount("object", 4)
+	game.AssertReachesBorder(ball, 5 * TickRate)
+
+	// the object is removed once it has moved past the border
+	game.Until(TickRate, ball.IsRemoved)
+	game.AssertRemoved(ball)
+	game.AssertCount("object", 3)
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	game := New(t)
+	player, _, _ := newScene(game)
+	player.AddTag("player")
+
+	game.Advance(500 * time.Millisecond)
+
+	snapshot := game.Snapshot()
+	if len(snapshot) != 3 {
+		t.Fatalf("expected 3 objects in the snapshot, found %d", len(snapshot))
+	}
+
+	buf, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := []ObjectState{}
+	if err := json.Unmarshal(buf, &loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	game.AssertSnapshot(loaded)
+
+	// the snapshot should no longer match after the game moves forward
+	game.Advance(500 * time.Millisecond)
+	if diff := CompareSnapshots(loaded, game.Snapshot()); len(diff) == 0 {
+		t.Errorf("snapshot still matches after the objects moved")
+	}
+}
+
+func TestDeterministic(t *testing.T) {
+	snapshots := [2][]ObjectState{}
+	for i := range snapshots {
+		game := New(t)
+		newScene(game)
+
+		game.Step(7 * TickRate + 3)
+		snapshots[i] = game.Snapshot()
+	}
+
+	for _, diff := range CompareSnapshots(snapshots[0], snapshots[1]) {
+		t.Error(diff)
+	}
+}
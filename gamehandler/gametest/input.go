@@ -0,0 +1,136 @@
+package gametest
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+// KeyDown sends a key down event to the window, the same way a desktop keyboard would
+func (game *Game) KeyDown(key fyne.KeyName){
+	if cb := game.canvas.OnKeyDown(); cb != nil {
+		cb(&fyne.KeyEvent{Name: key})
+	}
+}
+
+// KeyUp sends a key up event to the window, the same way a desktop keyboard would
+func (game *Game) KeyUp(key fyne.KeyName){
+	if cb := game.canvas.OnKeyUp(); cb != nil {
+		cb(&fyne.KeyEvent{Name: key})
+	}
+}
+
+// HoldKey sends a key down event, runs a number of ticks, then sends a key up event
+//
+// example: game.HoldKey(fyne.KeyD, 60)
+func (game *Game) HoldKey(key fyne.KeyName, ticks int){
+	game.KeyDown(key)
+	game.Step(ticks)
+	game.KeyUp(key)
+}
+
+// TypeKey sends a typed key event to the window (used by shortcuts like the F3 debug overlay)
+func (game *Game) TypeKey(key fyne.KeyName){
+	if cb := game.canvas.OnTypedKey(); cb != nil {
+		cb(&fyne.KeyEvent{Name: key})
+	}
+}
+
+// TypeText sends a typed rune event to the window for each character
+func (game *Game) TypeText(text string){
+	if cb := game.canvas.OnTypedRune(); cb != nil {
+		for _, r := range text {
+			cb(r)
+		}
+	}
+}
+
+// testApp is a fyne test app that reports a keyboard, and creates windows that accept desktop key events
+//
+// the fyne test app does not have a keyboard, which would stop objects from listening for key events
+type testApp struct {
+	fyne.App
+	driver *testDriver
+}
+
+type testDriver struct {
+	fyne.Driver
+}
+
+type keyboardDevice struct {
+	fyne.Device
+}
+
+// testWindow is a fyne test window with a canvas that accepts desktop key events
+type testWindow struct {
+	fyne.Window
+	canvas *keyCanvas
+}
+
+// keyCanvas adds the desktop.Canvas key down and key up events to a fyne test canvas
+type keyCanvas struct {
+	fyne.Canvas
+
+	onKeyDown func(*fyne.KeyEvent)
+	onKeyUp func(*fyne.KeyEvent)
+	mu sync.Mutex
+}
+
+func newTestApp() *testApp {
+	a := test.NewApp()
+	app := testApp{
+		App: a,
+		driver: &testDriver{a.Driver()},
+	}
+	fyne.SetCurrentApp(&app)
+	return &app
+}
+
+func (a *testApp) Driver() fyne.Driver {
+	return a.driver
+}
+
+func (a *testApp) NewWindow(title string) fyne.Window {
+	w := a.App.NewWindow(title)
+	return &testWindow{
+		Window: w,
+		canvas: &keyCanvas{Canvas: w.Canvas()},
+	}
+}
+
+func (d *testDriver) Device() fyne.Device {
+	return keyboardDevice{d.Driver.Device()}
+}
+
+func (keyboardDevice) HasKeyboard() bool {
+	return true
+}
+
+func (w *testWindow) Canvas() fyne.Canvas {
+	return w.canvas
+}
+
+func (c *keyCanvas) OnKeyDown() func(*fyne.KeyEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.onKeyDown
+}
+
+func (c *keyCanvas) SetOnKeyDown(cb func(*fyne.KeyEvent)){
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onKeyDown = cb
+}
+
+func (c *keyCanvas) OnKeyUp() func(*fyne.KeyEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.onKeyUp
+}
+
+func (c *keyCanvas) SetOnKeyUp(cb func(*fyne.KeyEvent)){
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onKeyUp = cb
+}
@@ -0,0 +1,171 @@
+package gametest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// Tolerance is the largest difference between 2 numbers that is still treated as equal when comparing snapshots
+var Tolerance float32 = 0.001
+
+// ObjectState is a copy of the state of an object, which can be compared with a state from an earlier test run
+type ObjectState struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	Width float32 `json:"width"`
+	Height float32 `json:"height"`
+	VelX float32 `json:"velX"`
+	VelY float32 `json:"velY"`
+	Rotation float32 `json:"rotation"`
+	ScaleX float32 `json:"scaleX"`
+	ScaleY float32 `json:"scaleY"`
+
+	OnBorderX int8 `json:"onBorderX"`
+	OnBorderY int8 `json:"onBorderY"`
+	Hidden bool `json:"hidden"`
+
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Snapshot returns the state of every object in the game, in the order they are updated
+func (game *Game) Snapshot() []ObjectState {
+	game.MU.Lock()
+	defer game.MU.Unlock()
+
+	list := []ObjectState{}
+	for _, object := range game.Query().All() {
+		list = append(list, ObjectState{
+			Type: object.Type(),
+			Name: object.Name(),
+
+			X: object.X,
+			Y: object.Y,
+			Width: object.Width,
+			Height: object.Height,
+			VelX: object.VelX,
+			VelY: object.VelY,
+			Rotation: object.Rotation,
+			ScaleX: object.ScaleX,
+			ScaleY: object.ScaleY,
+
+			OnBorderX: object.OnBorderX,
+			OnBorderY: object.OnBorderY,
+			Hidden: object.Hidden,
+
+			Tags: object.Tags(),
+		})
+	}
+
+	return list
+}
+
+// CompareSnapshots returns a list of the differences between 2 snapshots
+//
+// numbers are compared with the Tolerance
+//
+// returns an empty list if the snapshots match
+func CompareSnapshots(expected []ObjectState, actual []ObjectState) []string {
+	diff := []string{}
+
+	if len(expected) != len(actual) {
+		diff = append(diff, fmt.Sprintf("expected %d objects, found %d", len(expected), len(actual)))
+	}
+
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		e := expected[i]
+		a := actual[i]
+		name := fmt.Sprintf("object %d (%s %q)", i, e.Type, e.Name)
+
+		if e.Type != a.Type || e.Name != a.Name {
+			diff = append(diff, fmt.Sprintf("%s: found %s %q", name, a.Type, a.Name))
+			continue
+		}
+
+		nums := []struct {
+			field string
+			e, a float32
+		}{
+			{"X", e.X, a.X},
+			{"Y", e.Y, a.Y},
+			{"Width", e.Width, a.Width},
+			{"Height", e.Height, a.Height},
+			{"VelX", e.VelX, a.VelX},
+			{"VelY", e.VelY, a.VelY},
+			{"Rotation", e.Rotation, a.Rotation},
+			{"ScaleX", e.ScaleX, a.ScaleX},
+			{"ScaleY", e.ScaleY, a.ScaleY},
+		}
+
+		for _, n := range nums {
+			if math.Abs(float64(n.e - n.a)) > float64(Tolerance) {
+				diff = append(diff, fmt.Sprintf("%s: %s expected %g, found %g", name, n.field, n.e, n.a))
+			}
+		}
+
+		if e.OnBorderX != a.OnBorderX || e.OnBorderY != a.OnBorderY {
+			diff = append(diff, fmt.Sprintf("%s: border expected %d,%d, found %d,%d", name, e.OnBorderX, e.OnBorderY, a.OnBorderX, a.OnBorderY))
+		}
+
+		if e.Hidden != a.Hidden {
+			diff = append(diff, fmt.Sprintf("%s: Hidden expected %v, found %v", name, e.Hidden, a.Hidden))
+		}
+
+		if fmt.Sprint(e.Tags) != fmt.Sprint(a.Tags) {
+			diff = append(diff, fmt.Sprintf("%s: tags expected %v, found %v", name, e.Tags, a.Tags))
+		}
+	}
+
+	return diff
+}
+
+// AssertSnapshot fails the test if the current state of the game does not match a snapshot
+func (game *Game) AssertSnapshot(expected []ObjectState){
+	game.TB.Helper()
+
+	for _, diff := range CompareSnapshots(expected, game.Snapshot()) {
+		game.TB.Error(diff)
+	}
+}
+
+// AssertGolden compares the current state of the game with a snapshot saved in testdata/<name>.json
+//
+// if the file does not exist, or the GAMETEST_UPDATE environment variable is set to 1, the file is written instead
+//
+// example: GAMETEST_UPDATE=1 go test ./...
+func (game *Game) AssertGolden(name string){
+	game.TB.Helper()
+
+	path := filepath.Join("testdata", name + ".json")
+	actual := game.Snapshot()
+
+	buf, err := os.ReadFile(path)
+	if err != nil || os.Getenv("GAMETEST_UPDATE") == "1" {
+		buf, err := json.MarshalIndent(actual, "", "  ")
+		if err != nil {
+			game.TB.Fatal(err)
+		}
+
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			game.TB.Fatal(err)
+		}
+		if err := os.WriteFile(path, buf, 0644); err != nil {
+			game.TB.Fatal(err)
+		}
+		return
+	}
+
+	expected := []ObjectState{}
+	if err := json.Unmarshal(buf, &expected); err != nil {
+		game.TB.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	for _, diff := range CompareSnapshots(expected, actual) {
+		game.TB.Error(diff)
+	}
+}
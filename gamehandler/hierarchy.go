@@ -0,0 +1,141 @@
+package gamehandler
+
+// hitbox is the world space position and size of an object
+type hitbox struct {
+	X float32
+	Y float32
+	Width float32
+	Height float32
+
+	// Rotation is the world space rotation in degrees
+	Rotation float32
+}
+
+// SetParent attaches an object to a parent object
+//
+// once attached, the X and Y of the object are relative to its parent,
+// and the object will move, hide and be removed along with its parent
+//
+// the X and Y of the object are not changed, so they should be set to the offset from the parent
+//
+// the rotation and scale of a parent also apply to its children
+//
+// a nil parent detaches the object, and keeps it in the same position, rotation and scale in the world
+//
+// example: weapon.SetParent(player); weapon.X = 3; weapon.Y = 0
+func (object *GameObject) SetParent(parent *GameObject){
+	object.game.objectsMU.Lock()
+	defer object.game.objectsMU.Unlock()
+
+	// objects can only be attached to objects in the same game
+	if parent != nil && parent.game != object.game {
+		return
+	}
+
+	// prevent an object from becoming its own ancestor
+	for p := parent; p != nil; p = p.parent {
+		if p == object {
+			return
+		}
+	}
+
+	if object.parent != nil {
+		object.X, object.Y, object.Rotation, object.ScaleX, object.ScaleY = object.worldTransform()
+		object.parent.removeChild(object)
+	}
+
+	object.parent = parent
+	if parent != nil {
+		parent.children = append(parent.children, object)
+	}
+}
+
+// Parent returns the parent of an object
+//
+// returns nil if the object does not have a parent
+func (object *GameObject) Parent() *GameObject {
+	object.game.objectsMU.Lock()
+	defer object.game.objectsMU.Unlock()
+
+	return object.parent
+}
+
+// Children returns a list of the objects attached to this object
+func (object *GameObject) Children() []*GameObject {
+	object.game.objectsMU.Lock()
+	defer object.game.objectsMU.Unlock()
+
+	return append([]*GameObject{}, object.children...)
+}
+
+// WorldPos returns the position of an object in the world, after applying the position, rotation and scale of its parents
+//
+// for objects without a parent, this is the same as X and Y
+func (object *GameObject) WorldPos() (float32, float32) {
+	object.game.objectsMU.Lock()
+	defer object.game.objectsMU.Unlock()
+
+	return object.worldPos()
+}
+
+// IsHidden returns true if an object or any of its parents are hidden
+func (object *GameObject) IsHidden() bool {
+	object.game.objectsMU.Lock()
+	defer object.game.objectsMU.Unlock()
+
+	for o := object; o != nil; o = o.parent {
+		if o.Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+func (object *GameObject) worldPos() (float32, float32) {
+	x, y, _, _, _ := object.worldTransform()
+	return x, y
+}
+
+// hitbox returns the world space position, size and rotation of an object
+func (object *GameObject) hitbox() hitbox {
+	object.game.objectsMU.Lock()
+	x, y, rotation, scaleX, scaleY := object.worldTransform()
+	object.game.objectsMU.Unlock()
+
+	return hitbox{
+		X: x,
+		Y: y,
+		Width: object.Width * scaleX,
+		Height: object.Height * scaleY,
+		Rotation: rotation,
+	}
+}
+
+// removeChild removes a child from the list of children
+//
+// objectsMU should be locked before calling this method
+func (object *GameObject) removeChild(child *GameObject){
+	for i, c := range object.children {
+		if c == child {
+			object.children = append(object.children[:i:i], object.children[i+1:]...)
+			break
+		}
+	}
+}
+
+// handleHidden shows or hides the canvas object if the hidden state of the object has changed
+//
+// this method will be called by the draw method
+func (object *GameObject) handleHidden(){
+	hidden := object.IsHidden()
+	if hidden == object.hidden {
+		return
+	}
+
+	object.hidden = hidden
+	if hidden {
+		object.Object.Hide()
+	}else{
+		object.Object.Show()
+	}
+}
@@ -0,0 +1,249 @@
+package gamehandler
+
+import (
+	"game/enum/HUDAnchor"
+	"image/color"
+	"strconv"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// HUD is a screen space overlay that is drawn above the game canvas
+//
+// unlike game objects, HUD elements are positioned by the screen and use normal fyne widgets
+type HUD struct {
+	// Container holds the HUD elements and any open menu
+	Container *fyne.Container
+
+	anchors [9]*fyne.Container
+	menuBox *fyne.Container
+	menus map[string]fyne.CanvasObject
+	menu string
+
+	binds []*hudBind
+	mu sync.Mutex
+}
+
+// hudBind is a callback added with 'Bind' or 'BindTo'
+type hudBind struct {
+	obj fyne.CanvasObject
+	cb func()
+	removed bool
+}
+
+// NewHUD creates a new empty HUD overlay
+func NewHUD() *HUD {
+	hud := HUD{
+		menus: map[string]fyne.CanvasObject{},
+	}
+
+	for i := range hud.anchors {
+		hud.anchors[i] = container.NewVBox()
+	}
+
+	top := container.NewBorder(nil, nil, hud.anchors[HUDAnchor.TopLeft], hud.anchors[HUDAnchor.TopRight], container.NewCenter(hud.anchors[HUDAnchor.Top]))
+	middle := container.NewBorder(nil, nil, hud.anchors[HUDAnchor.Left], hud.anchors[HUDAnchor.Right], container.NewCenter(hud.anchors[HUDAnchor.Center]))
+	bottom := container.NewBorder(nil, nil, hud.anchors[HUDAnchor.BottomLeft], hud.anchors[HUDAnchor.BottomRight], container.NewCenter(hud.anchors[HUDAnchor.Bottom]))
+
+	hud.menuBox = container.NewMax()
+	hud.menuBox.Hide()
+
+	hud.Container = container.NewMax(container.NewBorder(top, bottom, nil, nil, middle), hud.menuBox)
+
+	return &hud
+}
+
+// Add adds a fyne canvas object to the HUD
+//
+// anchor: the position on the screen from the HUDAnchor enum
+func (hud *HUD) Add(anchor uint8, obj fyne.CanvasObject){
+	if anchor > HUDAnchor.BottomRight {
+		anchor = HUDAnchor.TopLeft
+	}
+
+	hud.anchors[anchor].Add(obj)
+	hud.anchors[anchor].Refresh()
+}
+
+// Remove removes a fyne canvas object from the HUD, along with any bindings added for it with 'BindTo'
+func (hud *HUD) Remove(obj fyne.CanvasObject){
+	for _, box := range hud.anchors {
+		box.Remove(obj)
+		box.Refresh()
+	}
+
+	hud.mu.Lock()
+	defer hud.mu.Unlock()
+
+	binds := []*hudBind{}
+	for _, bind := range hud.binds {
+		if bind.obj == obj {
+			bind.removed = true
+			continue
+		}
+		binds = append(binds, bind)
+	}
+	hud.binds = binds
+}
+
+// Bind runs a callback on every draw to keep a HUD element up to date with the game
+//
+// the callback runs while the game is locked, so it can safely read game data
+//
+// returns a function that removes the binding
+func (hud *HUD) Bind(cb func()) func() {
+	return hud.BindTo(nil, cb)
+}
+
+// BindTo runs a callback on every draw to keep a HUD element up to date with the game
+//
+// the binding is removed when the object is removed from the HUD with 'Remove'
+//
+// returns a function that removes the binding
+func (hud *HUD) BindTo(obj fyne.CanvasObject, cb func()) func() {
+	bind := hudBind{obj: obj, cb: cb}
+
+	hud.mu.Lock()
+	hud.binds = append(hud.binds, &bind)
+	hud.mu.Unlock()
+
+	return func(){
+		hud.mu.Lock()
+		defer hud.mu.Unlock()
+
+		bind.removed = true
+		for i, b := range hud.binds {
+			if b == &bind {
+				hud.binds = append(hud.binds[:i:i], hud.binds[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// AddLabel adds a text label to the HUD, which is updated from the text callback on every draw
+//
+// the label stops updating when it is removed with 'Remove'
+func (hud *HUD) AddLabel(anchor uint8, text func() string) *widget.Label {
+	label := widget.NewLabel(text())
+	hud.Add(anchor, label)
+
+	hud.BindTo(label, func() {
+		if t := text(); t != label.Text {
+			label.SetText(t)
+		}
+	})
+
+	return label
+}
+
+// AddCounter adds a text label to the HUD, which displays a name followed by a number
+//
+// example: hud.AddCounter(HUDAnchor.TopLeft, "Score", func() int { return score }) // Score: 10
+func (hud *HUD) AddCounter(anchor uint8, name string, value func() int) *widget.Label {
+	return hud.AddLabel(anchor, func() string {
+		return name + ": " + strconv.Itoa(value())
+	})
+}
+
+// AddBar adds a progress bar to the HUD, which is updated from the value callback on every draw
+//
+// the value should be between 0 and 1
+//
+// the bar stops updating when it is removed with 'Remove'
+//
+// example: health bars
+func (hud *HUD) AddBar(anchor uint8, value func() float64) *widget.ProgressBar {
+	bar := widget.NewProgressBar()
+	bar.TextFormatter = func() string {
+		return ""
+	}
+	bar.SetValue(value())
+	hud.Add(anchor, bar)
+
+	hud.BindTo(bar, func() {
+		if v := value(); v != bar.Value {
+			bar.SetValue(v)
+		}
+	})
+
+	return bar
+}
+
+// AddMenu adds a menu screen to the HUD, which is hidden until it is opened with 'ShowMenu'
+//
+// the objects are stacked in the center of the screen, above a darkened background
+func (hud *HUD) AddMenu(name string, objects ...fyne.CanvasObject){
+	bg := canvas.NewRectangle(color.RGBA{0, 0, 0, 180})
+	menu := container.NewMax(bg, container.NewCenter(container.NewVBox(objects...)))
+
+	hud.mu.Lock()
+	defer hud.mu.Unlock()
+
+	hud.menus[name] = menu
+	if hud.menu == name {
+		hud.menuBox.Objects = []fyne.CanvasObject{menu}
+		hud.menuBox.Refresh()
+	}
+}
+
+// ShowMenu opens a menu screen that was added with 'AddMenu'
+//
+// only one menu can be open at a time, so any other open menu will be closed
+func (hud *HUD) ShowMenu(name string){
+	hud.mu.Lock()
+	defer hud.mu.Unlock()
+
+	menu, ok := hud.menus[name]
+	if !ok {
+		return
+	}
+
+	hud.menu = name
+	hud.menuBox.Objects = []fyne.CanvasObject{menu}
+	hud.menuBox.Show()
+	hud.menuBox.Refresh()
+}
+
+// HideMenu closes the currently open menu screen
+func (hud *HUD) HideMenu(){
+	hud.mu.Lock()
+	defer hud.mu.Unlock()
+
+	hud.menu = ""
+	hud.menuBox.Hide()
+	hud.menuBox.Objects = []fyne.CanvasObject{}
+}
+
+// Menu returns the name of the currently open menu screen
+//
+// returns an empty string if no menu is open
+func (hud *HUD) Menu() string {
+	hud.mu.Lock()
+	defer hud.mu.Unlock()
+
+	return hud.menu
+}
+
+// update runs the HUD bindings
+//
+// this method will be called by the draw method
+func (hud *HUD) update(){
+	hud.mu.Lock()
+	binds := hud.binds
+	hud.mu.Unlock()
+
+	for _, bind := range binds {
+		hud.mu.Lock()
+		removed := bind.removed
+		hud.mu.Unlock()
+
+		if !removed {
+			bind.cb()
+		}
+	}
+}
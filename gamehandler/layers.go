@@ -0,0 +1,256 @@
+package gamehandler
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// AddLayer adds a new render layer above all of the existing layers
+//
+// by default, every object type listed in config.yml already has its own layer
+//
+// if the layer already exists, nothing will change
+func (game *Game) AddLayer(layer string){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	game.addLayer(layer)
+}
+
+// SetLayerIndex moves a render layer to a new position in the draw order
+//
+// layer 0 is drawn at the bottom, and the last layer is drawn on top
+//
+// a negative index counts down from the top layer (-1 is the top layer)
+func (game *Game) SetLayerIndex(layer string, index int){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	game.setLayerIndex(layer, index)
+}
+
+// GetLayerIndex returns the position of a render layer in the draw order
+//
+// returns -1 if the layer does not exist
+func (game *Game) GetLayerIndex(layer string) int {
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	return game.layerIndex(layer)
+}
+
+// MoveLayerAbove moves a render layer so it is drawn directly above the target layer
+func (game *Game) MoveLayerAbove(layer string, target string){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	i := game.layerIndex(layer)
+	t := game.layerIndex(target)
+	if i == -1 || t == -1 || i == t {
+		return
+	}
+
+	if i < t {
+		game.setLayerIndex(layer, t)
+	}else{
+		game.setLayerIndex(layer, t+1)
+	}
+}
+
+// MoveLayerBelow moves a render layer so it is drawn directly below the target layer
+func (game *Game) MoveLayerBelow(layer string, target string){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	i := game.layerIndex(layer)
+	t := game.layerIndex(target)
+	if i == -1 || t == -1 || i == t {
+		return
+	}
+
+	if i < t {
+		game.setLayerIndex(layer, t-1)
+	}else{
+		game.setLayerIndex(layer, t)
+	}
+}
+
+func (game *Game) addLayer(layer string){
+	if _, ok := game.CanvasList[layer]; ok {
+		return
+	}
+
+	if game.CanvasList == nil {
+		game.CanvasList = map[string]*fyne.Container{}
+	}
+
+	game.CanvasList[layer] = container.NewWithoutLayout()
+	game.CanvasListKeys = append(game.CanvasListKeys, layer)
+
+	if game.Canvas != nil {
+		game.Canvas.Add(game.CanvasList[layer])
+		game.Canvas.Refresh()
+	}
+}
+
+func (game *Game) layerIndex(layer string) int {
+	for i, key := range game.CanvasListKeys {
+		if key == layer {
+			return i
+		}
+	}
+	return -1
+}
+
+func (game *Game) setLayerIndex(layer string, index int){
+	i := game.layerIndex(layer)
+	if i == -1 {
+		return
+	}
+
+	if index < 0 {
+		index += len(game.CanvasListKeys)
+	}
+	if index < 0 {
+		index = 0
+	}else if index >= len(game.CanvasListKeys) {
+		index = len(game.CanvasListKeys) - 1
+	}
+
+	if i == index {
+		return
+	}
+
+	keys := append(game.CanvasListKeys[:i:i], game.CanvasListKeys[i+1:]...)
+	keys = append(keys[:index], append([]string{layer}, keys[index:]...)...)
+	game.CanvasListKeys = keys
+
+	if game.Canvas == nil {
+		return
+	}
+
+	// keep any extra canvas objects that are not layers below the layers
+	objects := []fyne.CanvasObject{}
+	for _, obj := range game.Canvas.Objects {
+		isLayer := false
+		for _, box := range game.CanvasList {
+			if obj == box {
+				isLayer = true
+				break
+			}
+		}
+		if !isLayer {
+			objects = append(objects, obj)
+		}
+	}
+
+	for _, key := range game.CanvasListKeys {
+		objects = append(objects, game.CanvasList[key])
+	}
+
+	game.Canvas.Objects = objects
+	game.Canvas.Refresh()
+}
+
+// sortLayerLater marks a layer to be sorted by ZIndex at the end of the next draw
+//
+// objectsMU should be locked before calling this method
+func (game *Game) sortLayerLater(layer string){
+	if game.layerSort == nil {
+		game.layerSort = map[string]bool{}
+	}
+	game.layerSort[layer] = true
+}
+
+// sortLayers sorts the canvas objects of any layers that have changed by ZIndex
+func (game *Game) sortLayers(){
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	for layer := range game.layerSort {
+		delete(game.layerSort, layer)
+
+		box, ok := game.CanvasList[layer]
+		if !ok {
+			continue
+		}
+
+		list := []*GameObject{}
+		for _, objList := range game.objects {
+			for _, object := range objList {
+				if object.layer == layer {
+					list = append(list, object)
+				}
+			}
+		}
+
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].zIndex != list[j].zIndex {
+				return list[i].zIndex < list[j].zIndex
+			}
+			return list[i].order < list[j].order
+		})
+
+		// keep any extra canvas objects that are not game objects at the bottom of the layer
+		objects := []fyne.CanvasObject{}
+		for _, obj := range box.Objects {
+			isObject := false
+			for _, object := range list {
+				if obj == object.Object {
+					isObject = true
+					break
+				}
+			}
+			if !isObject {
+				objects = append(objects, obj)
+			}
+		}
+
+		for _, object := range list {
+			objects = append(objects, object.Object)
+		}
+
+		box.Objects = objects
+		box.Refresh()
+	}
+}
+
+// handleLayer moves an object to a new layer, or resorts its layer, if its Layer or ZIndex has changed
+//
+// this method will be called by the draw method
+func (object *GameObject) handleLayer(game *Game){
+	if object.Layer == object.layer && object.ZIndex == object.zIndex {
+		return
+	}
+
+	game.objectsMU.Lock()
+	defer game.objectsMU.Unlock()
+
+	if object.Layer == "" {
+		object.Layer = object.objType
+	}
+
+	if object.Layer != object.layer {
+		if box, ok := game.CanvasList[object.layer]; ok {
+			box.Remove(object.Object)
+			box.Refresh()
+		}
+
+		game.addLayer(object.Layer)
+		game.CanvasList[object.Layer].Add(object.Object)
+		object.layer = object.Layer
+	}
+
+	object.zIndex = object.ZIndex
+
+	if object.zIndex != 0 {
+		if game.layerZIndex == nil {
+			game.layerZIndex = map[string]bool{}
+		}
+		game.layerZIndex[object.layer] = true
+	}
+
+	game.sortLayerLater(object.layer)
+}
@@ -0,0 +1,97 @@
+package gamehandler
+
+import (
+	"time"
+)
+
+// Pause pauses the game
+//
+// while paused, objects will stop moving and updating, unless they have 'IgnorePause' enabled
+func (game *Game) Pause(){
+	game.timeMU.Lock()
+	game.paused = true
+	game.timeMU.Unlock()
+}
+
+// Resume unpauses the game
+func (game *Game) Resume(){
+	game.timeMU.Lock()
+	game.paused = false
+	game.timeMU.Unlock()
+}
+
+// IsPaused returns true if the game is paused
+func (game *Game) IsPaused() bool {
+	game.timeMU.Lock()
+	defer game.timeMU.Unlock()
+
+	return game.paused
+}
+
+// SetTimeScale changes the speed of game time
+//
+// 1 is normal speed, 0.5 is half speed (slow motion), and 2 is double speed
+//
+// default: 1
+func (game *Game) SetTimeScale(scale float32){
+	if scale < 0 {
+		scale = 0
+	}
+
+	game.timeMU.Lock()
+	game.timeScale = scale
+	game.timeScaleSet = true
+	game.timeMU.Unlock()
+}
+
+// GetTimeScale returns the current speed of game time
+func (game *Game) GetTimeScale() float32 {
+	game.timeMU.Lock()
+	defer game.timeMU.Unlock()
+
+	if !game.timeScaleSet {
+		return 1
+	}
+	return game.timeScale
+}
+
+// setThreadTime adds the current pause state and time scale to the thread info
+//
+// this also stores the current FPS of the loop for 'game.LoopFPS'
+func (game *Game) setThreadTime(loop uint8, thread *ThreadInfo){
+	if thread.delta == 0 {
+		thread.delta = thread.Delta
+	}
+
+	game.timeMU.Lock()
+	game.loopFPS[loop] = thread.FPS
+	game.timeMU.Unlock()
+
+	thread.Paused = game.IsPaused()
+	if thread.Paused {
+		thread.TimeScale = 0
+	}else{
+		thread.TimeScale = game.GetTimeScale()
+	}
+
+	thread.Delta = time.Duration(float64(thread.delta) * float64(thread.TimeScale))
+}
+
+// threadTime returns the thread info an object should update with
+//
+// returns nil if the object should not update because the game is paused
+func (object *GameObject) threadTime(thread *ThreadInfo) *ThreadInfo {
+	if !thread.Paused {
+		return thread
+	}
+
+	if !object.IgnorePause {
+		return nil
+	}
+
+	// objects that ignore the pause keep moving at normal speed
+	t := *thread
+	t.TimeScale = 1
+	t.Delta = t.delta
+	return &t
+}
@@ -0,0 +1,272 @@
+package gamehandler
+
+import (
+	"encoding/json"
+	"os"
+	"runtime/metrics"
+	"sort"
+	"time"
+)
+
+var loopNames = [4]string{"Update", "Draw", "UpdateSlow", "UpdateBasic"}
+
+// Profile is a snapshot of the timing data collected while profiling is enabled
+//
+// all durations are stored in nanoseconds when converted to JSON
+type Profile struct {
+	// Duration is how long profiling has been running since it was enabled or reset
+	Duration time.Duration `json:"duration"`
+
+	Loops []LoopProfile `json:"loops"`
+
+	// Objects is sorted by TotalTime, with the slowest objects first
+	Objects []ObjectProfile `json:"objects"`
+}
+
+// LoopProfile is the timing data of a game loop
+type LoopProfile struct {
+	Loop string `json:"loop"`
+	Ticks uint64 `json:"ticks"`
+
+	// MissedFrames is the number of ticks that started more than a frame later than they should have,
+	// plus the number of ticks that were skipped because the loop fell too far behind
+	MissedFrames uint64 `json:"missedFrames"`
+
+	TotalTime time.Duration `json:"totalTime"`
+	AvgTime time.Duration `json:"avgTime"`
+	MaxTime time.Duration `json:"maxTime"`
+	LastTime time.Duration `json:"lastTime"`
+
+	// LockWait is the total time the loop spent waiting for game.MU before each tick
+	LockWait time.Duration `json:"lockWait"`
+	MaxLockWait time.Duration `json:"maxLockWait"`
+
+	// Allocs and AllocBytes count the heap allocations made during each tick
+	//
+	// note: these are approximate, and are counted for the whole program, so allocations made by other goroutines at the same time are included
+	Allocs uint64 `json:"allocs"`
+	AllocBytes uint64 `json:"allocBytes"`
+}
+
+// ObjectProfile is the timing data of the callbacks of an object on a game loop
+//
+// this includes the main callback of the loop and any callbacks added with 'AddUpdate'
+type ObjectProfile struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Loop string `json:"loop"`
+
+	Calls uint64 `json:"calls"`
+	TotalTime time.Duration `json:"totalTime"`
+	AvgTime time.Duration `json:"avgTime"`
+	MaxTime time.Duration `json:"maxTime"`
+}
+
+type callProfile struct {
+	calls uint64
+	total time.Duration
+	max time.Duration
+}
+
+// SetProfiling enables or disables profiling of the game loops and object callbacks
+//
+// enabling profiling resets any data that was collected before
+//
+// profiling adds a small amount of overhead to each loop, so it should be disabled when it is not needed
+func (game *Game) SetProfiling(enabled bool){
+	game.profileMU.Lock()
+	defer game.profileMU.Unlock()
+
+	if enabled && !game.profiling.Load() {
+		game.resetProfile()
+	}
+	game.profiling.Store(enabled)
+}
+
+// IsProfiling returns true if profiling is enabled
+func (game *Game) IsProfiling() bool {
+	return game.profiling.Load()
+}
+
+// ResetProfile clears any profiling data that has been collected
+func (game *Game) ResetProfile(){
+	game.profileMU.Lock()
+	defer game.profileMU.Unlock()
+
+	game.resetProfile()
+}
+
+// Profile returns a snapshot of the profiling data
+func (game *Game) Profile() Profile {
+	game.profileMU.Lock()
+	defer game.profileMU.Unlock()
+
+	profile := Profile{
+		Loops: make([]LoopProfile, len(game.loopProfile)),
+		Objects: []ObjectProfile{},
+	}
+
+	if !game.profileStart.IsZero() {
+		profile.Duration = time.Since(game.profileStart)
+	}
+
+	for i, loop := range game.loopProfile {
+		loop.Loop = loopNames[i]
+		if loop.Ticks != 0 {
+			loop.AvgTime = loop.TotalTime / time.Duration(loop.Ticks)
+		}
+		profile.Loops[i] = loop
+	}
+
+	for object, loops := range game.objectProfile {
+		for i, call := range loops {
+			if call.calls == 0 {
+				continue
+			}
+
+			profile.Objects = append(profile.Objects, ObjectProfile{
+				Type: object.objType,
+				Name: object.name,
+				Loop: loopNames[i],
+				Calls: call.calls,
+				TotalTime: call.total,
+				AvgTime: call.total / time.Duration(call.calls),
+				MaxTime: call.max,
+			})
+		}
+	}
+
+	sort.SliceStable(profile.Objects, func(i, j int) bool {
+		return profile.Objects[i].TotalTime > profile.Objects[j].TotalTime
+	})
+
+	return profile
+}
+
+// ProfileJSON returns the profiling data as JSON
+func (game *Game) ProfileJSON() ([]byte, error) {
+	return json.MarshalIndent(game.Profile(), "", "  ")
+}
+
+// WriteProfile writes the profiling data to a JSON file
+//
+// example: game.WriteProfile("./profile.json")
+func (game *Game) WriteProfile(path string) error {
+	buf, err := game.ProfileJSON()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0644)
+}
+
+// resetProfile clears the profiling data
+//
+// profileMU should be locked before calling this method
+func (game *Game) resetProfile(){
+	game.loopProfile = [4]LoopProfile{}
+	game.objectProfile = map[*GameObject]*[4]callProfile{}
+	game.profileStart = time.Now()
+}
+
+// profileLoop starts timing a tick of a game loop
+//
+// returns a function that should be called at the end of the tick
+func (game *Game) profileLoop(loop uint8, thread *ThreadInfo) func() {
+	if !game.profiling.Load() {
+		return func(){}
+	}
+
+	start := time.Now()
+	allocs, bytes := readAllocs()
+
+	return func(){
+		elapsed := time.Since(start)
+		endAllocs, endBytes := readAllocs()
+
+		game.profileMU.Lock()
+		defer game.profileMU.Unlock()
+
+		stats := &game.loopProfile[loop]
+		stats.Ticks++
+		stats.TotalTime += elapsed
+		stats.LastTime = elapsed
+		if elapsed > stats.MaxTime {
+			stats.MaxTime = elapsed
+		}
+
+		stats.LockWait += thread.LockWait
+		if thread.LockWait > stats.MaxLockWait {
+			stats.MaxLockWait = thread.LockWait
+		}
+
+		if thread.Late != 0 {
+			stats.MissedFrames++
+		}
+		stats.MissedFrames += uint64(thread.Skipped)
+
+		stats.Allocs += endAllocs - allocs
+		stats.AllocBytes += endBytes - bytes
+	}
+}
+
+// profileObjectStart returns the time an object callback started, or a zero time if profiling is disabled
+func (game *Game) profileObjectStart() time.Time {
+	if !game.profiling.Load() {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// profileObject adds the time since start to the profile of an object
+func (game *Game) profileObject(loop uint8, object *GameObject, start time.Time){
+	if start.IsZero() {
+		return
+	}
+	elapsed := time.Since(start)
+
+	game.profileMU.Lock()
+	defer game.profileMU.Unlock()
+
+	if game.objectProfile == nil {
+		game.objectProfile = map[*GameObject]*[4]callProfile{}
+	}
+
+	loops, ok := game.objectProfile[object]
+	if !ok {
+		loops = &[4]callProfile{}
+		game.objectProfile[object] = loops
+	}
+
+	call := &loops[loop]
+	call.calls++
+	call.total += elapsed
+	if elapsed > call.max {
+		call.max = elapsed
+	}
+}
+
+// profileForget removes the profile of an object that was removed from the game
+func (game *Game) profileForget(object *GameObject){
+	game.profileMU.Lock()
+	defer game.profileMU.Unlock()
+
+	delete(game.objectProfile, object)
+}
+
+// readAllocs returns the total number of heap allocations, and the total number of bytes allocated by the program
+func readAllocs() (uint64, uint64) {
+	samples := []metrics.Sample{
+		{Name: "/gc/heap/allocs:objects"},
+		{Name: "/gc/heap/allocs:bytes"},
+	}
+	metrics.Read(samples)
+
+	var allocs, bytes uint64
+	if samples[0].Value.Kind() == metrics.KindUint64 {
+		allocs = samples[0].Value.Uint64()
+	}
+	if samples[1].Value.Kind() == metrics.KindUint64 {
+		bytes = samples[1].Value.Uint64()
+	}
+	return allocs, bytes
+}
@@ -0,0 +1,257 @@
+package gamehandler
+
+import (
+	"sort"
+)
+
+// Query finds objects that match a list of filters
+//
+// example: game.Query().Type("enemy").Tag("burning").Region(-10, -10, 10, 10).All()
+type Query struct {
+	game *Game
+	types []string
+	names []string
+	tags []string
+	region *[4]float32
+	where []func(object *GameObject) bool
+}
+
+// ID returns the unique id of an object
+func (object *GameObject) ID() string {
+	return object.id
+}
+
+// Type returns the object type of an object
+func (object *GameObject) Type() string {
+	return object.objType
+}
+
+// Name returns the name of an object
+func (object *GameObject) Name() string {
+	return object.name
+}
+
+// AddTag adds tags to an object
+//
+// tags can be used to find objects with 'game.Query'
+func (object *GameObject) AddTag(tags ...string){
+	object.MU.Lock()
+	defer object.MU.Unlock()
+
+	if object.tags == nil {
+		object.tags = map[string]bool{}
+	}
+
+	for _, tag := range tags {
+		object.tags[tag] = true
+	}
+}
+
+// RemoveTag removes tags from an object
+func (object *GameObject) RemoveTag(tags ...string){
+	object.MU.Lock()
+	defer object.MU.Unlock()
+
+	for _, tag := range tags {
+		delete(object.tags, tag)
+	}
+}
+
+// HasTag returns true if an object has all of the tags
+func (object *GameObject) HasTag(tags ...string) bool {
+	object.MU.Lock()
+	defer object.MU.Unlock()
+
+	for _, tag := range tags {
+		if !object.tags[tag] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Tags returns a sorted list of the tags on an object
+func (object *GameObject) Tags() []string {
+	object.MU.Lock()
+	defer object.MU.Unlock()
+
+	list := []string{}
+	for tag := range object.tags {
+		list = append(list, tag)
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+// Query creates a new query for finding objects
+//
+// with no filters, the query matches every object
+func (game *Game) Query() *Query {
+	return &Query{game: game}
+}
+
+// Type only matches objects with one of the object types
+func (query *Query) Type(objTypes ...string) *Query {
+	query.types = append(query.types, objTypes...)
+	return query
+}
+
+// Name only matches objects with one of the names
+func (query *Query) Name(names ...string) *Query {
+	query.names = append(query.names, names...)
+	return query
+}
+
+// Tag only matches objects that have all of the tags
+func (query *Query) Tag(tags ...string) *Query {
+	query.tags = append(query.tags, tags...)
+	return query
+}
+
+// Region only matches objects that are touching an area of the game
+//
+// x1, y1: the top left corner of the area
+//
+// x2, y2: the bottom right corner of the area
+func (query *Query) Region(x1, y1, x2, y2 float32) *Query {
+	if x1 > x2 {
+		x1, x2 = x2, x1
+	}
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+
+	query.region = &[4]float32{x1, y1, x2, y2}
+	return query
+}
+
+// Where only matches objects where the callback returns true
+func (query *Query) Where(cb func(object *GameObject) bool) *Query {
+	query.where = append(query.where, cb)
+	return query
+}
+
+// All returns a list of every matching object
+//
+// the list is a snapshot, so it is safe to keep even if objects are added or removed
+func (query *Query) All() []*GameObject {
+	list := []*GameObject{}
+	query.Each(func(object *GameObject) bool {
+		list = append(list, object)
+		return true
+	})
+	return list
+}
+
+// First returns the first matching object
+//
+// returns nil if no objects match
+func (query *Query) First() *GameObject {
+	var res *GameObject
+	query.Each(func(object *GameObject) bool {
+		res = object
+		return false
+	})
+	return res
+}
+
+// Count returns the number of matching objects
+func (query *Query) Count() int {
+	count := 0
+	query.Each(func(object *GameObject) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Each runs a callback for every matching object
+//
+// return false from the callback to stop early
+//
+// the objects are checked from a snapshot, so it is safe to add or remove objects from the callback
+func (query *Query) Each(cb func(object *GameObject) bool){
+	for _, object := range query.snapshot() {
+		if query.match(object) && !cb(object) {
+			return
+		}
+	}
+}
+
+// snapshot returns a copy of the list of objects that could match the query
+func (query *Query) snapshot() []*GameObject {
+	query.game.objectsMU.Lock()
+	defer query.game.objectsMU.Unlock()
+
+	list := []*GameObject{}
+
+	if len(query.types) != 0 {
+		for _, objType := range query.types {
+			list = append(list, query.game.objects[objType]...)
+		}
+		return list
+	}
+
+	// keep the same order as the game loops
+	done := map[string]bool{}
+	for _, objType := range query.game.CanvasListKeys {
+		list = append(list, query.game.objects[objType]...)
+		done[objType] = true
+	}
+
+	other := []string{}
+	for objType := range query.game.objects {
+		if !done[objType] {
+			other = append(other, objType)
+		}
+	}
+	sort.Strings(other)
+
+	for _, objType := range other {
+		list = append(list, query.game.objects[objType]...)
+	}
+
+	return list
+}
+
+func (query *Query) match(object *GameObject) bool {
+	if object.IsRemoved() {
+		return false
+	}
+
+	if len(query.names) != 0 {
+		found := false
+		for _, name := range query.names {
+			if object.name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(query.tags) != 0 && !object.HasTag(query.tags...) {
+		return false
+	}
+
+	if r := query.region; r != nil {
+		b := object.hitbox()
+		width, height := b.extents()
+		if b.X + width < r[0] || b.X - width > r[2] ||
+		b.Y + height < r[1] || b.Y - height > r[3] {
+			return false
+		}
+	}
+
+	for _, cb := range query.where {
+		if !cb(object) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,100 @@
+package gamehandler
+
+import (
+	"context"
+)
+
+// Context returns a context that is canceled when the game shuts down
+//
+// this can be used to stop any extra goroutines or requests that belong to the game
+func (game *Game) Context() context.Context {
+	game.ctxMU.Lock()
+	defer game.ctxMU.Unlock()
+
+	game.initContext()
+	return game.ctx
+}
+
+// Go runs a function on a new goroutine, which the game will wait for when it shuts down
+//
+// the function should return soon after ctx is done
+//
+// returns false (and does not run the function) if the game has already shut down
+//
+// example: game.Go(func(ctx context.Context) { GameLoop(ctx, game, 60, gamehandler.Update) })
+func (game *Game) Go(cb func(ctx context.Context)) bool {
+	game.ctxMU.Lock()
+	game.initContext()
+	if game.ctx.Err() != nil {
+		game.ctxMU.Unlock()
+		return false
+	}
+
+	ctx := game.ctx
+	game.running.Add(1)
+	game.ctxMU.Unlock()
+
+	go func(){
+		defer game.running.Done()
+		cb(ctx)
+	}()
+
+	return true
+}
+
+// OnShutdown adds a callback that runs when the game shuts down
+//
+// the callbacks run after all of the game loops have stopped, in the reverse order they were added (like defer)
+//
+// example: autosaving or flushing logs
+func (game *Game) OnShutdown(cb func(game *Game)){
+	game.ctxMU.Lock()
+	defer game.ctxMU.Unlock()
+
+	game.shutdownHooks = append(game.shutdownHooks, cb)
+}
+
+// Shutdown stops the game
+//
+// the context of the game is canceled, then this waits for the game loops (and any goroutines started with 'game.Go') to finish,
+// and runs the OnShutdown callbacks while the game is locked
+//
+// calling this more than once will only shut down the game once
+//
+// note: this method should not be called from inside a game loop, because it would wait for itself to finish (use `go game.Shutdown()` instead)
+func (game *Game) Shutdown(){
+	game.ctxMU.Lock()
+	game.initContext()
+	game.cancel()
+	game.ctxMU.Unlock()
+
+	game.shutdownOnce.Do(func(){
+		game.running.Wait()
+
+		game.ctxMU.Lock()
+		hooks := game.shutdownHooks
+		game.shutdownHooks = nil
+		game.ctxMU.Unlock()
+
+		game.MU.Lock()
+		defer game.MU.Unlock()
+
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i](game)
+		}
+	})
+}
+
+// IsShutdown returns true if the game has started shutting down
+func (game *Game) IsShutdown() bool {
+	return game.Context().Err() != nil
+}
+
+// initContext creates the context of the game if it does not exist yet
+//
+// ctxMU should be locked before calling this method
+func (game *Game) initContext(){
+	if game.ctx == nil {
+		game.ctx, game.cancel = context.WithCancel(context.Background())
+	}
+}
@@ -0,0 +1,143 @@
+package gamehandler
+
+import (
+	"time"
+
+	"game/enum/Loop"
+)
+
+// Timer is a scheduled callback that runs on a game loop, measured in game time
+type Timer struct {
+	game *Game
+	loop uint8
+	due time.Duration
+	interval time.Duration
+	repeat bool
+	canceled bool
+	cb func(game *Game, thread *ThreadInfo)
+}
+
+// Time returns the amount of game time that has passed since the game started
+//
+// game time does not pass while the game is paused, and it speeds up or slows down with the time scale
+func (game *Game) Time() time.Duration {
+	game.timersMU.Lock()
+	defer game.timersMU.Unlock()
+
+	return game.time
+}
+
+// After runs a callback once, after a delay in game time
+//
+// the callback runs on the chosen game loop while the game is locked (from the Loop enum)
+//
+// example: game.After(2 * time.Second, Loop.Update, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {})
+func (game *Game) After(delay time.Duration, loop uint8, cb func(game *Game, thread *ThreadInfo)) *Timer {
+	return game.addTimer(delay, loop, false, cb)
+}
+
+// Every runs a callback repeatedly, each time the interval of game time passes
+//
+// the callback runs on the chosen game loop while the game is locked (from the Loop enum)
+//
+// example: game.Every(1 * time.Second, Loop.UpdateBasic, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {})
+func (game *Game) Every(interval time.Duration, loop uint8, cb func(game *Game, thread *ThreadInfo)) *Timer {
+	return game.addTimer(interval, loop, true, cb)
+}
+
+// Cancel stops a timer from running again
+func (timer *Timer) Cancel(){
+	timer.game.timersMU.Lock()
+	timer.canceled = true
+	timer.game.timersMU.Unlock()
+}
+
+// Active returns true if the timer is still waiting to run
+func (timer *Timer) Active() bool {
+	timer.game.timersMU.Lock()
+	defer timer.game.timersMU.Unlock()
+
+	return !timer.canceled
+}
+
+func (game *Game) addTimer(delay time.Duration, loop uint8, repeat bool, cb func(game *Game, thread *ThreadInfo)) *Timer {
+	if repeat && delay <= 0 {
+		delay = 1
+	}
+
+	if int(loop) >= len(game.loopFPS) {
+		loop = Loop.Update
+	}
+
+	game.timersMU.Lock()
+	defer game.timersMU.Unlock()
+
+	timer := Timer{
+		game: game,
+		loop: loop,
+		due: game.time + delay,
+		interval: delay,
+		repeat: repeat,
+		cb: cb,
+	}
+
+	game.timers = append(game.timers, &timer)
+
+	return &timer
+}
+
+// addTime moves the game time forward
+//
+// this method will be called by the draw method
+func (game *Game) addTime(delta time.Duration){
+	game.timersMU.Lock()
+	game.time += delta
+	game.timersMU.Unlock()
+}
+
+// runTimers runs any timers that are due on a game loop
+func (game *Game) runTimers(loop uint8, thread *ThreadInfo){
+	game.timersMU.Lock()
+
+	now := game.time
+	run := []*Timer{}
+	timers := game.timers[:0]
+	for _, timer := range game.timers {
+		if timer.canceled {
+			continue
+		}
+
+		if timer.loop == loop && timer.due <= now {
+			run = append(run, timer)
+
+			if !timer.repeat {
+				continue
+			}
+
+			// skip any missed intervals, rather than running them all at once
+			timer.due += timer.interval
+			if timer.due <= now {
+				timer.due = now + timer.interval
+			}
+		}
+
+		timers = append(timers, timer)
+	}
+	game.timers = timers
+
+	game.timersMU.Unlock()
+
+	for _, timer := range run {
+		// a timer may be canceled by an earlier callback
+		game.timersMU.Lock()
+		canceled := timer.canceled
+		if !timer.repeat {
+			timer.canceled = true
+		}
+		game.timersMU.Unlock()
+
+		if !canceled {
+			timer.cb(game, thread)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package gamehandler_test
+
+import (
+	"game/enum/Loop"
+	"game/gamehandler"
+	"game/gamehandler/gametest"
+	"testing"
+	"time"
+)
+
+// ticksUntil runs ticks until a timer has fired, and returns the number of ticks that ran
+func ticksUntil(t *testing.T, game *gametest.Game, fired *int, maxTicks int) int {
+	t.Helper()
+
+	ticks, ok := game.Until(maxTicks, func() bool {
+		return *fired != 0
+	})
+	if !ok {
+		t.Fatalf("the timer did not fire within %d ticks", maxTicks)
+	}
+	return ticks
+}
+
+func TestTimerAfter(t *testing.T) {
+	game := gametest.New(t)
+
+	fired := 0
+	timer := game.After(100 * time.Millisecond, Loop.Draw, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		fired++
+	})
+
+	// 12 ticks is just under 100ms of game time
+	if ticks := ticksUntil(t, game, &fired, gametest.TickRate); ticks != 13 {
+		t.Errorf("expected the timer to fire on tick 13, found %d", ticks)
+	}
+	if game.Time() < 100 * time.Millisecond {
+		t.Errorf("the timer fired early at %v", game.Time())
+	}
+	if timer.Active() {
+		t.Errorf("the timer is still active after firing")
+	}
+
+	game.Advance(time.Second)
+	if fired != 1 {
+		t.Errorf("expected the timer to fire once, found %d", fired)
+	}
+
+	// timers on a slower loop fire on the first run of that loop after they are due
+	//
+	// Update runs on every 2nd tick, before the Draw loop moves game time forward
+	game = gametest.New(t)
+	fired = 0
+	game.After(100 * time.Millisecond, Loop.Update, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		fired++
+	})
+	if ticks := ticksUntil(t, game, &fired, gametest.TickRate); ticks != 14 {
+		t.Errorf("expected the Update timer to fire on tick 14, found %d", ticks)
+	}
+}
+
+func TestTimerEvery(t *testing.T) {
+	game := gametest.New(t)
+
+	fired := 0
+	timer := game.Every(50 * time.Millisecond, Loop.Draw, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		fired++
+	})
+
+	// 1 second is just under 120 ticks, so the timer fires at 50ms, 100ms, ... 950ms
+	game.Step(gametest.TickRate)
+	if fired != 19 {
+		t.Errorf("expected the timer to fire 19 times, found %d", fired)
+	}
+
+	timer.Cancel()
+	if timer.Active() {
+		t.Errorf("the timer is still active after being canceled")
+	}
+
+	game.Step(gametest.TickRate)
+	if fired != 19 {
+		t.Errorf("the timer fired %d times after being canceled", fired - 19)
+	}
+}
+
+func TestTimerCancel(t *testing.T) {
+	game := gametest.New(t)
+
+	fired := 0
+	timer := game.After(100 * time.Millisecond, Loop.Draw, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		fired++
+	})
+
+	game.Step(6)
+	timer.Cancel()
+	game.Advance(time.Second)
+
+	if fired != 0 {
+		t.Errorf("a canceled timer fired %d times", fired)
+	}
+}
+
+func TestTimerPause(t *testing.T) {
+	game := gametest.New(t)
+
+	fired := 0
+	game.After(100 * time.Millisecond, Loop.Draw, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		fired++
+	})
+
+	game.Step(6)
+	game.Pause()
+
+	now := game.Time()
+	game.Advance(time.Second)
+	if game.Time() != now {
+		t.Errorf("game time moved from %v to %v while paused", now, game.Time())
+	}
+	if fired != 0 {
+		t.Errorf("the timer fired while the game was paused")
+	}
+
+	// the remaining 6 ticks of game time run after the game resumes
+	game.Resume()
+	if ticks := ticksUntil(t, game, &fired, gametest.TickRate); ticks != 7 {
+		t.Errorf("expected the timer to fire 7 ticks after resuming, found %d", ticks)
+	}
+}
+
+func TestTimerTimeScale(t *testing.T) {
+	for _, test := range []struct {
+		scale float32
+		ticks int
+	}{
+		{1, 13},
+		{2, 7},
+		{0.5, 25},
+	} {
+		game := gametest.New(t)
+		game.SetTimeScale(test.scale)
+
+		fired := 0
+		game.After(100 * time.Millisecond, Loop.Draw, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+			fired++
+		})
+
+		if ticks := ticksUntil(t, game, &fired, gametest.TickRate); ticks != test.ticks {
+			t.Errorf("expected the timer to fire on tick %d with a time scale of %g, found %d", test.ticks, test.scale, ticks)
+		}
+	}
+}
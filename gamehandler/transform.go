@@ -0,0 +1,412 @@
+package gamehandler
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// rotationSteps is the number of angles an image can be drawn at (5 degrees each)
+//
+// rotations are rounded to the nearest step, so every angle of an image fits in the cache
+const rotationSteps = 72
+
+// maxRotationAspects is the number of aspect ratios cached for each source image
+const maxRotationAspects = 8
+
+// maxRotationSources is the number of source images that rotations are cached for
+const maxRotationSources = 64
+
+// rotationCache is the list of rotated copies of a source image
+//
+// the cache is shared by every object that draws the same image
+type rotationCache struct {
+	src image.Image
+	aspects []*rotationAspect
+	used uint64
+}
+
+// rotationAspect is the list of rotated copies of an image, stretched to an aspect ratio
+type rotationAspect struct {
+	aspect float32
+	steps [rotationSteps]image.Image
+}
+
+var rotationCaches map[string]*rotationCache
+var rotationUsed uint64
+var rotationCachesMU sync.Mutex
+
+var rectImages map[color.NRGBA]*image.NRGBA
+var rectImagesMU sync.Mutex
+
+// NewRectImage creates a solid colored image that can be used as the canvas object of an object
+//
+// unlike canvas.NewRectangle, an image can be rotated by the renderer
+//
+// objects with the same color share the same image (and rotation cache)
+func NewRectImage(c color.Color) *canvas.Image {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+
+	rectImagesMU.Lock()
+	defer rectImagesMU.Unlock()
+
+	if img, ok := rectImages[nc]; ok {
+		return canvas.NewImageFromImage(img)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i] = nc.R
+		img.Pix[i+1] = nc.G
+		img.Pix[i+2] = nc.B
+		img.Pix[i+3] = nc.A
+	}
+
+	if rectImages == nil {
+		rectImages = map[color.NRGBA]*image.NRGBA{}
+	}
+	rectImages[nc] = img
+
+	return canvas.NewImageFromImage(img)
+}
+
+// worldTransform returns the position, rotation and scale of an object in the world, after applying the transforms of its parents
+//
+// objectsMU should be locked before calling this method
+func (object *GameObject) worldTransform() (x float32, y float32, rotation float32, scaleX float32, scaleY float32) {
+	x = object.X
+	y = object.Y
+	rotation = object.Rotation
+	scaleX = object.getScaleX()
+	scaleY = object.getScaleY()
+
+	for p := object.parent; p != nil; p = p.parent {
+		psx := p.getScaleX()
+		psy := p.getScaleY()
+
+		x, y = rotatePoint(x * psx, y * psy, p.Rotation)
+		x += p.X
+		y += p.Y
+
+		rotation += p.Rotation
+		scaleX *= psx
+		scaleY *= psy
+	}
+
+	return x, y, rotation, scaleX, scaleY
+}
+
+func (object *GameObject) getScaleX() float32 {
+	if object.ScaleX == 0 {
+		return 1
+	}
+	return object.ScaleX
+}
+
+func (object *GameObject) getScaleY() float32 {
+	if object.ScaleY == 0 {
+		return 1
+	}
+	return object.ScaleY
+}
+
+// rotatePoint rotates a point around 0, 0 clockwise by a number of degrees
+func rotatePoint(x, y float32, rotation float32) (float32, float32) {
+	if rotation == 0 {
+		return x, y
+	}
+
+	sin, cos := math.Sincos(float64(rotation) * math.Pi / 180)
+	return float32(float64(x) * cos - float64(y) * sin), float32(float64(x) * sin + float64(y) * cos)
+}
+
+// rotatedExtents returns the half width and half height of the box that fits around a rotated box
+func rotatedExtents(width, height float32, rotation float32) (float32, float32) {
+	if rotation == 0 {
+		return width, height
+	}
+
+	sin, cos := math.Sincos(float64(rotation) * math.Pi / 180)
+	sin = math.Abs(sin)
+	cos = math.Abs(cos)
+	return float32(float64(width) * cos + float64(height) * sin), float32(float64(width) * sin + float64(height) * cos)
+}
+
+// extents returns the half width and half height of the box that fits around the hitbox
+func (b hitbox) extents() (float32, float32) {
+	return rotatedExtents(b.Width, b.Height, b.Rotation)
+}
+
+// isBoxColliding checks if 2 rotated boxes overlap using the separating axis theorem
+func isBoxColliding(b1, b2 hitbox) bool {
+	if math.Mod(float64(b1.Rotation), 360) == 0 && math.Mod(float64(b2.Rotation), 360) == 0 {
+		return (b1.X + b1.Width > b2.X - b2.Width && b1.X - b1.Width < b2.X + b2.Width) &&
+		(b1.Y + b1.Height > b2.Y - b2.Height && b1.Y - b1.Height < b2.Y + b2.Height)
+	}
+
+	sin1, cos1 := math.Sincos(float64(b1.Rotation) * math.Pi / 180)
+	sin2, cos2 := math.Sincos(float64(b2.Rotation) * math.Pi / 180)
+
+	axes := [4][2]float64{
+		{cos1, sin1}, {-sin1, cos1},
+		{cos2, sin2}, {-sin2, cos2},
+	}
+
+	dx := float64(b2.X - b1.X)
+	dy := float64(b2.Y - b1.Y)
+
+	for _, axis := range axes {
+		r1 := float64(b1.Width) * math.Abs(axes[0][0] * axis[0] + axes[0][1] * axis[1]) + float64(b1.Height) * math.Abs(axes[1][0] * axis[0] + axes[1][1] * axis[1])
+		r2 := float64(b2.Width) * math.Abs(axes[2][0] * axis[0] + axes[2][1] * axis[1]) + float64(b2.Height) * math.Abs(axes[3][0] * axis[0] + axes[3][1] * axis[1])
+
+		if math.Abs(dx * axis[0] + dy * axis[1]) >= r1 + r2 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// toBoxSpace moves a hitbox into the unrotated space of a box, so the box can be treated as axis aligned
+func toBoxSpace(box hitbox, other hitbox) (hitbox, hitbox) {
+	if box.Rotation == 0 {
+		return box, other
+	}
+
+	x, y := rotatePoint(other.X - box.X, other.Y - box.Y, -box.Rotation)
+	other.X = box.X + x
+	other.Y = box.Y + y
+	other.Rotation -= box.Rotation
+	box.Rotation = 0
+
+	return box, other
+}
+
+// render moves and resizes the canvas object of an object to match its world transform
+//
+// this method will be called by the draw method
+func (object *GameObject) render(game *Game){
+	b := object.hitbox()
+	width, height := b.Width, b.Height
+
+	if img, ok := object.Object.(*canvas.Image); ok && (b.Rotation != 0 || object.rotCache != nil) {
+		if object.rotateImage(img, b.Rotation, b.Width / b.Height) {
+			width, height = b.extents()
+		}
+	}
+
+	object.Object.Move(fyne.NewPos(((b.X - width) * game.Size.Scale) + (game.Size.RealWidth/2), ((b.Y - height) * game.Size.Scale) + (game.Size.RealHeight/2)))
+	object.Object.Resize(fyne.NewSize((width * 2) * game.Size.Scale, (height * 2) * game.Size.Scale))
+	object.Object.Refresh()
+}
+
+// rotateImage replaces the image of a canvas image with a pre-rendered rotated copy
+//
+// rotations are rounded to the nearest of the 'rotationSteps' angles, and cached for each source image
+//
+// aspect: the width divided by the height of the object, which the image is stretched to before rotating
+//
+// returns false if the image could not be rotated
+func (object *GameObject) rotateImage(img *canvas.Image, rotation float32, aspect float32) bool {
+	step := int(math.Round(float64(rotation) * rotationSteps / 360)) % rotationSteps
+	if step < 0 {
+		step += rotationSteps
+	}
+
+	// reload the source image if it was changed
+	if object.rotCache == nil || img.Resource != nil || img.File != "" || img.Image != object.rotImg {
+		cache := loadRotationCache(img)
+		if cache == nil {
+			return false
+		}
+
+		object.rotCache = cache
+		object.rotStep = -1
+	}
+
+	if step == object.rotStep && aspect == object.rotAspect {
+		return true
+	}
+	object.rotStep = step
+	object.rotAspect = aspect
+
+	if step == 0 {
+		object.rotImg = object.rotCache.src
+	}else{
+		object.rotImg = object.rotCache.get(step, aspect)
+	}
+
+	img.Resource = nil
+	img.File = ""
+	img.Image = object.rotImg
+
+	return true
+}
+
+// loadRotationCache returns the shared rotation cache for the image of a canvas image
+//
+// returns nil if the image could not be loaded
+func loadRotationCache(img *canvas.Image) *rotationCache {
+	var key string
+	if img.Resource != nil {
+		h := fnv.New64a()
+		h.Write(img.Resource.Content())
+		key = fmt.Sprintf("res:%s:%x", img.Resource.Name(), h.Sum64())
+	}else if img.File != "" {
+		key = "file:" + img.File
+	}else if img.Image != nil {
+		key = fmt.Sprintf("img:%p", img.Image)
+	}else{
+		return nil
+	}
+
+	rotationCachesMU.Lock()
+	rotationUsed++
+	if cache, ok := rotationCaches[key]; ok {
+		cache.used = rotationUsed
+		rotationCachesMU.Unlock()
+		return cache
+	}
+	rotationCachesMU.Unlock()
+
+	src := loadImage(img)
+	if src == nil {
+		return nil
+	}
+
+	rotationCachesMU.Lock()
+	defer rotationCachesMU.Unlock()
+
+	// another object may have loaded the same image while this one was decoding it
+	if cache, ok := rotationCaches[key]; ok {
+		return cache
+	}
+
+	if rotationCaches == nil {
+		rotationCaches = map[string]*rotationCache{}
+	}
+
+	// limit the memory used by the cache, by removing the least recently used image
+	if len(rotationCaches) >= maxRotationSources {
+		oldKey := ""
+		var oldUsed uint64
+		for k, cache := range rotationCaches {
+			if oldKey == "" || cache.used < oldUsed {
+				oldKey = k
+				oldUsed = cache.used
+			}
+		}
+		delete(rotationCaches, oldKey)
+	}
+
+	cache := &rotationCache{src: src, used: rotationUsed}
+	rotationCaches[key] = cache
+	return cache
+}
+
+// get returns a copy of the source image, stretched to an aspect ratio and rotated to a step
+//
+// the image is rotated the first time a step is used, and cached for every object that uses the same source image
+func (cache *rotationCache) get(step int, aspect float32) image.Image {
+	rotationCachesMU.Lock()
+	var set *rotationAspect
+	for i, a := range cache.aspects {
+		if a.aspect == aspect {
+			// keep the most recently used aspect ratio at the end of the list
+			set = a
+			cache.aspects = append(append(cache.aspects[:i:i], cache.aspects[i+1:]...), set)
+			break
+		}
+	}
+	if set == nil {
+		// limit the memory used by the cache, by removing the least recently used aspect ratio
+		if len(cache.aspects) >= maxRotationAspects {
+			cache.aspects = cache.aspects[1:]
+		}
+		set = &rotationAspect{aspect: aspect}
+		cache.aspects = append(cache.aspects, set)
+	}
+	img := set.steps[step]
+	rotationCachesMU.Unlock()
+
+	if img != nil {
+		return img
+	}
+
+	img = rotateImage(cache.src, float64(step) * 360 / rotationSteps, float64(aspect))
+
+	rotationCachesMU.Lock()
+	set.steps[step] = img
+	rotationCachesMU.Unlock()
+
+	return img
+}
+
+// loadImage returns the image.Image of a canvas image
+func loadImage(img *canvas.Image) image.Image {
+	if img.Resource != nil {
+		if src, _, err := image.Decode(bytes.NewReader(img.Resource.Content())); err == nil {
+			return src
+		}
+		return nil
+	}
+
+	if img.File != "" {
+		if buf, err := ReadFile(img.File); err == nil {
+			if src, _, err := image.Decode(bytes.NewReader(buf)); err == nil {
+				return src
+			}
+		}
+		return nil
+	}
+
+	return img.Image
+}
+
+// rotateImage creates a copy of an image stretched to an aspect ratio, and rotated clockwise by a number of degrees
+//
+// the new image is large enough to fit the rotated corners
+func rotateImage(src image.Image, rotation float64, aspect float64) image.Image {
+	bounds := src.Bounds()
+	srcW := float64(bounds.Dx())
+	srcH := float64(bounds.Dy())
+
+	w := srcW
+	h := srcW / aspect
+	if aspect <= 0 || !(h >= 1) || math.IsInf(h, 0) {
+		h = srcH
+	}
+
+	sin, cos := math.Sincos(rotation * math.Pi / 180)
+	dw := math.Ceil(w * math.Abs(cos) + h * math.Abs(sin))
+	dh := math.Ceil(w * math.Abs(sin) + h * math.Abs(cos))
+
+	dst := image.NewNRGBA(image.Rect(0, 0, int(dw), int(dh)))
+
+	for y := 0; y < int(dh); y++ {
+		for x := 0; x < int(dw); x++ {
+			// rotate each pixel back to find where it came from
+			dx := float64(x) + 0.5 - dw / 2
+			dy := float64(y) + 0.5 - dh / 2
+			sx := dx * cos + dy * sin + w / 2
+			sy := -dx * sin + dy * cos + h / 2
+
+			if sx < 0 || sy < 0 || sx >= w || sy >= h {
+				continue
+			}
+
+			dst.Set(x, y, src.At(bounds.Min.X + int(sx * srcW / w), bounds.Min.Y + int(sy * srcH / h)))
+		}
+	}
+
+	return dst
+}
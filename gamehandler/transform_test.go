@@ -0,0 +1,119 @@
+package gamehandler
+
+import (
+	"testing"
+)
+
+// oldBoxColliding is the axis aligned box check used before objects could rotate
+func oldBoxColliding(b1, b2 hitbox) bool {
+	return (b1.X + b1.Width > b2.X - b2.Width && b1.X - b1.Width < b2.X + b2.Width) &&
+	(b1.Y + b1.Height > b2.Y - b2.Height && b1.Y - b1.Height < b2.Y + b2.Height)
+}
+
+func TestBoxCollidingUnrotated(t *testing.T) {
+	boxes := []hitbox{
+		{X: 0, Y: 0, Width: 2, Height: 2},
+		{X: 3, Y: 0, Width: 2, Height: 2},
+		{X: 4, Y: 0, Width: 2, Height: 2},
+		{X: 3, Y: 3, Width: 1, Height: 1},
+		{X: -5, Y: 1, Width: 10, Height: 0.5},
+		{X: 0, Y: -6, Width: 1, Height: 4},
+		{X: 20, Y: 20, Width: 1, Height: 1},
+	}
+
+	for _, b1 := range boxes {
+		for _, b2 := range boxes {
+			expect := oldBoxColliding(b1, b2)
+			if res := isBoxColliding(b1, b2); res != expect {
+				t.Errorf("%v and %v: expected %v, found %v", b1, b2, expect, res)
+			}
+
+			// a full turn is the same as no rotation
+			b1.Rotation = 360
+			b2.Rotation = -360
+			if res := isBoxColliding(b1, b2); res != expect {
+				t.Errorf("%v and %v: expected %v, found %v", b1, b2, expect, res)
+			}
+			b1.Rotation = 0
+			b2.Rotation = 0
+		}
+	}
+
+	// boxes that only touch on an edge do not collide
+	if isBoxColliding(hitbox{X: 0, Y: 0, Width: 2, Height: 2}, hitbox{X: 4, Y: 0, Width: 2, Height: 2}) {
+		t.Errorf("boxes touching on an edge should not collide")
+	}
+}
+
+func TestBoxCollidingRotated(t *testing.T) {
+	// 2 long thin boxes rotated 45 degrees, side by side
+	//
+	// the boxes that fit around them overlap, but the boxes themselves do not
+	b1 := hitbox{X: 0, Y: 0, Width: 10, Height: 1, Rotation: 45}
+	b2 := hitbox{X: -3.5, Y: 3.5, Width: 10, Height: 1, Rotation: 45}
+
+	w1, h1 := b1.extents()
+	w2, h2 := b2.extents()
+	if !oldBoxColliding(hitbox{X: b1.X, Y: b1.Y, Width: w1, Height: h1}, hitbox{X: b2.X, Y: b2.Y, Width: w2, Height: h2}) {
+		t.Fatalf("expected the bounding boxes to overlap")
+	}
+
+	if isBoxColliding(b1, b2) || isBoxColliding(b2, b1) {
+		t.Errorf("rotated boxes with overlapping bounding boxes should not collide")
+	}
+
+	// moving the boxes closer together makes them collide
+	b2.X = -1
+	b2.Y = 1
+	if !isBoxColliding(b1, b2) || !isBoxColliding(b2, b1) {
+		t.Errorf("expected the rotated boxes to collide")
+	}
+
+	// a rotated box that reaches further than it could without rotating
+	b1 = hitbox{X: 0, Y: 0, Width: 10, Height: 1, Rotation: 90}
+	b2 = hitbox{X: 0, Y: 8, Width: 1, Height: 1}
+	if !isBoxColliding(b1, b2) {
+		t.Errorf("expected a box rotated 90 degrees to reach a box below it")
+	}
+	b1.Rotation = 0
+	if isBoxColliding(b1, b2) {
+		t.Errorf("expected an unrotated box not to reach a box below it")
+	}
+}
+
+func TestBoxCollidingScaled(t *testing.T) {
+	game := &Game{}
+	obj1 := &GameObject{game: game, X: 0, Y: 0, Width: 2, Height: 2}
+	obj2 := &GameObject{game: game, X: 5, Y: 0, Width: 2, Height: 2}
+
+	if isBoxColliding(obj1.hitbox(), obj2.hitbox()) {
+		t.Errorf("expected the boxes not to collide before scaling")
+	}
+
+	// scaling the height does not make the box wider
+	obj1.ScaleY = 2
+	if isBoxColliding(obj1.hitbox(), obj2.hitbox()) {
+		t.Errorf("expected the boxes not to collide after scaling the height")
+	}
+
+	obj1.ScaleX = 2
+	if !isBoxColliding(obj1.hitbox(), obj2.hitbox()) {
+		t.Errorf("expected the boxes to collide after scaling the width")
+	}
+
+	// a child is scaled with its parent
+	obj1.ScaleX = 1
+	obj1.ScaleY = 1
+	child := &GameObject{game: game, X: 2, Y: 0, Width: 1, Height: 1, parent: obj1}
+	if isBoxColliding(child.hitbox(), obj2.hitbox()) {
+		t.Errorf("expected the child not to collide before scaling its parent")
+	}
+
+	obj1.ScaleX = 2
+	if b := child.hitbox(); b.X != 4 || b.Width != 2 {
+		t.Errorf("expected the child to move to 4 with a width of 2, found %g with a width of %g", b.X, b.Width)
+	}
+	if !isBoxColliding(child.hitbox(), obj2.hitbox()) {
+		t.Errorf("expected the child to collide after scaling its parent")
+	}
+}
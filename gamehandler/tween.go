@@ -0,0 +1,462 @@
+package gamehandler
+
+import (
+	"game/enum/TweenProp"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// Tween animates the properties of an object over game time
+type Tween struct {
+	game *Game
+	object *GameObject
+
+	duration time.Duration
+	delay time.Duration
+	elapsed time.Duration
+	ease func(t float64) float64
+
+	props []*tweenProp
+	color *tweenColor
+
+	yoyo bool
+	repeat int
+	reverse bool
+
+	started bool
+	waiting bool
+	canceled bool
+
+	onComplete func(game *Game, thread *ThreadInfo)
+	next *Tween
+}
+
+type tweenProp struct {
+	prop uint8
+	from float32
+	to float32
+}
+
+type tweenColor struct {
+	from color.NRGBA
+	to color.NRGBA
+}
+
+// Tween creates a new tween to animate the properties of an object
+//
+// the tween starts on the next draw, and runs on the draw loop using game time
+//
+// if the object is removed, the tween (and any tweens after it) will be canceled
+//
+// ease: an easing function from the ease package (nil for linear)
+//
+// example: game.Tween(object, 1 * time.Second, ease.OutQuad).To(TweenProp.X, 20).To(TweenProp.Alpha, 0)
+func (game *Game) Tween(object *GameObject, duration time.Duration, ease func(t float64) float64) *Tween {
+	tween := Tween{
+		game: game,
+		object: object,
+		duration: duration,
+		ease: ease,
+	}
+
+	game.tweensMU.Lock()
+	game.tweens = append(game.tweens, &tween)
+	game.tweensMU.Unlock()
+
+	return &tween
+}
+
+// Sequence runs a list of tweens one after the other
+//
+// returns the first tween in the sequence
+func (game *Game) Sequence(tweens ...*Tween) *Tween {
+	if len(tweens) == 0 {
+		return nil
+	}
+
+	for i := 1; i < len(tweens); i++ {
+		tweens[i-1].Then(tweens[i])
+	}
+
+	return tweens[0]
+}
+
+// To sets a property of the object to animate, and the value it should end at
+//
+// prop: a property from the TweenProp enum
+func (tween *Tween) To(prop uint8, value float32) *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.props = append(tween.props, &tweenProp{prop: prop, to: value})
+	return tween
+}
+
+// ToColor animates the color of the canvas object of the object
+//
+// this works with rectangles, circles, lines and text (or a container holding one of them)
+func (tween *Tween) ToColor(c color.Color) *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.color = &tweenColor{to: color.NRGBAModel.Convert(c).(color.NRGBA)}
+	return tween
+}
+
+// Delay waits an amount of game time before starting the tween
+func (tween *Tween) Delay(delay time.Duration) *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.delay = delay
+	return tween
+}
+
+// Yoyo plays the tween backwards after it reaches the end, so the object returns to where it started
+//
+// the tween finishes after the backwards play, and 'Repeat' will repeat both directions
+func (tween *Tween) Yoyo() *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.yoyo = true
+	return tween
+}
+
+// Repeat plays the tween again a number of times after it finishes
+//
+// a negative number will repeat the tween forever
+func (tween *Tween) Repeat(times int) *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.repeat = times
+	return tween
+}
+
+// OnComplete runs a callback when the tween finishes
+func (tween *Tween) OnComplete(cb func(game *Game, thread *ThreadInfo)) *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.onComplete = cb
+	return tween
+}
+
+// Then starts the next tween after this tween finishes
+//
+// returns the next tween, so more tweens can be chained after it
+func (tween *Tween) Then(next *Tween) *Tween {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	tween.next = next
+	next.waiting = true
+	return next
+}
+
+// Cancel stops the tween where it is, without running its OnComplete callback or any tweens after it
+func (tween *Tween) Cancel(){
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	for t := tween; t != nil; t = t.next {
+		t.canceled = true
+	}
+}
+
+// Active returns true if the tween is still running or waiting to run
+func (tween *Tween) Active() bool {
+	tween.game.tweensMU.Lock()
+	defer tween.game.tweensMU.Unlock()
+
+	return !tween.canceled
+}
+
+// runTweens moves all of the running tweens forward
+//
+// this method will be called by the draw method
+func (game *Game) runTweens(thread *ThreadInfo){
+	game.tweensMU.Lock()
+
+	done := []*Tween{}
+	tweens := game.tweens[:0]
+	for _, tween := range game.tweens {
+		if tween.canceled {
+			continue
+		}
+
+		// stop animating objects that have been removed
+		if tween.object.IsRemoved() {
+			for t := tween; t != nil; t = t.next {
+				t.canceled = true
+			}
+			continue
+		}
+
+		if tween.waiting {
+			tweens = append(tweens, tween)
+			continue
+		}
+
+		objThread := tween.object.threadTime(thread)
+		if objThread == nil {
+			tweens = append(tweens, tween)
+			continue
+		}
+
+		if tween.update(objThread.Delta) {
+			tween.canceled = true
+			if tween.next != nil {
+				tween.next.waiting = false
+			}
+			done = append(done, tween)
+			continue
+		}
+
+		tweens = append(tweens, tween)
+	}
+	game.tweens = tweens
+
+	game.tweensMU.Unlock()
+
+	for _, tween := range done {
+		if tween.onComplete != nil {
+			tween.onComplete(game, tween.object.threadTime(thread))
+		}
+	}
+}
+
+// update moves a tween forward
+//
+// returns true if the tween has finished
+func (tween *Tween) update(delta time.Duration) bool {
+	if tween.delay > 0 {
+		tween.delay -= delta
+		if tween.delay > 0 {
+			return false
+		}
+		delta = -tween.delay
+		tween.delay = 0
+	}
+
+	if !tween.started {
+		tween.start()
+	}
+
+	tween.elapsed += delta
+
+	t := float64(1)
+	if tween.duration > 0 && tween.elapsed < tween.duration {
+		t = float64(tween.elapsed) / float64(tween.duration)
+	}
+
+	if tween.reverse {
+		t = 1 - t
+	}
+
+	if tween.ease != nil {
+		t = tween.ease(t)
+	}
+
+	tween.apply(float32(t))
+
+	if tween.elapsed < tween.duration {
+		return false
+	}
+
+	// carry the time past the end into the next play, so repeating tweens do not fall behind game time
+	if tween.duration > 0 {
+		tween.elapsed -= tween.duration
+	}else{
+		tween.elapsed = 0
+	}
+
+	// play the tween backwards before finishing
+	if tween.yoyo && !tween.reverse {
+		tween.reverse = true
+		return false
+	}
+
+	if tween.repeat == 0 {
+		return true
+	}
+
+	if tween.repeat > 0 {
+		tween.repeat--
+	}
+
+	tween.reverse = false
+	return false
+}
+
+// start stores the starting values of the properties being animated
+func (tween *Tween) start(){
+	tween.started = true
+
+	for _, prop := range tween.props {
+		switch prop.prop {
+		case TweenProp.X:
+			prop.from = tween.object.X
+		case TweenProp.Y:
+			prop.from = tween.object.Y
+		case TweenProp.Width:
+			prop.from = tween.object.Width
+		case TweenProp.Height:
+			prop.from = tween.object.Height
+		case TweenProp.Alpha:
+			prop.from = getCanvasAlpha(tween.object.Object)
+		case TweenProp.Rotation:
+			prop.from = tween.object.Rotation
+		case TweenProp.ScaleX:
+			prop.from = tween.object.getScaleX()
+		case TweenProp.ScaleY:
+			prop.from = tween.object.getScaleY()
+		}
+	}
+
+	if tween.color != nil {
+		if c, ok := getCanvasColor(tween.object.Object); ok {
+			tween.color.from = color.NRGBAModel.Convert(c).(color.NRGBA)
+		}else{
+			tween.color = nil
+		}
+	}
+}
+
+// apply sets the properties being animated to a point between their start and end values
+//
+// t: 0 is the start, and 1 is the end
+func (tween *Tween) apply(t float32){
+	for _, prop := range tween.props {
+		value := prop.from + ((prop.to - prop.from) * t)
+
+		switch prop.prop {
+		case TweenProp.X:
+			tween.object.X = value
+		case TweenProp.Y:
+			tween.object.Y = value
+		case TweenProp.Width:
+			tween.object.Width = value
+		case TweenProp.Height:
+			tween.object.Height = value
+		case TweenProp.Alpha:
+			setCanvasAlpha(tween.object.Object, value)
+		case TweenProp.Rotation:
+			tween.object.Rotation = value
+		case TweenProp.ScaleX:
+			tween.object.ScaleX = value
+		case TweenProp.ScaleY:
+			tween.object.ScaleY = value
+		}
+	}
+
+	if tween.color != nil {
+		from := tween.color.from
+		to := tween.color.to
+		setCanvasColor(tween.object.Object, color.NRGBA{
+			R: lerpUint8(from.R, to.R, t),
+			G: lerpUint8(from.G, to.G, t),
+			B: lerpUint8(from.B, to.B, t),
+			A: lerpUint8(from.A, to.A, t),
+		})
+	}
+}
+
+func lerpUint8(from, to uint8, t float32) uint8 {
+	v := float32(from) + ((float32(to) - float32(from)) * t)
+	if v < 0 {
+		return 0
+	}else if v > 255 {
+		return 255
+	}
+	return uint8(v + 0.5)
+}
+
+// getCanvasColor returns the main color of a canvas object
+//
+// if the canvas object is a container, the first child with a color is used
+func getCanvasColor(obj fyne.CanvasObject) (color.Color, bool) {
+	switch o := obj.(type) {
+	case *canvas.Rectangle:
+		return o.FillColor, o.FillColor != nil
+	case *canvas.Circle:
+		return o.FillColor, o.FillColor != nil
+	case *canvas.Text:
+		return o.Color, o.Color != nil
+	case *canvas.Line:
+		return o.StrokeColor, o.StrokeColor != nil
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			if c, ok := getCanvasColor(child); ok {
+				return c, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// setCanvasColor sets the main color of a canvas object
+//
+// if the canvas object is a container, the first child with a color is used
+func setCanvasColor(obj fyne.CanvasObject, c color.Color) bool {
+	switch o := obj.(type) {
+	case *canvas.Rectangle:
+		o.FillColor = c
+		return true
+	case *canvas.Circle:
+		o.FillColor = c
+		return true
+	case *canvas.Text:
+		o.Color = c
+		return true
+	case *canvas.Line:
+		o.StrokeColor = c
+		return true
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			if _, ok := getCanvasColor(child); ok {
+				return setCanvasColor(child, c)
+			}
+		}
+	}
+
+	return false
+}
+
+// getCanvasAlpha returns the opacity of a canvas object between 0 and 1
+func getCanvasAlpha(obj fyne.CanvasObject) float32 {
+	if img, ok := obj.(*canvas.Image); ok {
+		return float32(1 - img.Translucency)
+	}
+
+	if c, ok := getCanvasColor(obj); ok {
+		return float32(color.NRGBAModel.Convert(c).(color.NRGBA).A) / 255
+	}
+
+	return 1
+}
+
+// setCanvasAlpha sets the opacity of a canvas object between 0 and 1
+func setCanvasAlpha(obj fyne.CanvasObject, alpha float32){
+	if alpha < 0 {
+		alpha = 0
+	}else if alpha > 1 {
+		alpha = 1
+	}
+
+	if img, ok := obj.(*canvas.Image); ok {
+		img.Translucency = float64(1 - alpha)
+		return
+	}
+
+	if c, ok := getCanvasColor(obj); ok {
+		nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+		nc.A = uint8(alpha * 255 + 0.5)
+		setCanvasColor(obj, nc)
+	}
+}
@@ -0,0 +1,86 @@
+package gamehandler_test
+
+import (
+	"game/enum/TweenProp"
+	"game/gamehandler"
+	"game/gamehandler/gametest"
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// newBox adds a box to a test game
+func newBox(game *gametest.Game, name string, x, y float32) *gamehandler.GameObject {
+	return game.Add("object", name, x, y, 4, 4, func(game *gamehandler.Game) fyne.CanvasObject {
+		return canvas.NewRectangle(color.White)
+	})
+}
+
+// expectTweenX checks the position of an object moving from 0 to 10 in a tween that has been running since game time 'start'
+func expectTweenX(t *testing.T, game *gametest.Game, object *gamehandler.GameObject, start time.Duration, duration time.Duration, yoyo bool) {
+	t.Helper()
+
+	elapsed := game.Time() - start
+	leg := elapsed / duration
+	x := float64(elapsed % duration) / float64(duration) * 10
+	if yoyo && leg % 2 == 1 {
+		x = 10 - x
+	}
+
+	if math.Abs(float64(object.X) - x) > 0.01 {
+		t.Errorf("expected the object to be at %g after %v, found %g", x, elapsed, object.X)
+	}
+}
+
+func TestTweenRepeatKeepsTime(t *testing.T) {
+	game := gametest.New(t)
+	box := newBox(game, "box", 0, 0)
+
+	// 100ms does not fit evenly into the ticks, so each play ends part way through a tick
+	duration := 100 * time.Millisecond
+	game.Tween(box, duration, nil).To(TweenProp.X, 10).Repeat(-1)
+
+	// after a few seconds, the tween should still match game time
+	game.Step(606)
+	expectTweenX(t, game, box, 0, duration, false)
+
+	game.Step(600)
+	expectTweenX(t, game, box, 0, duration, false)
+}
+
+func TestTweenYoyoKeepsTime(t *testing.T) {
+	game := gametest.New(t)
+	box := newBox(game, "box", 0, 0)
+
+	duration := 100 * time.Millisecond
+	game.Tween(box, duration, nil).To(TweenProp.X, 10).Yoyo().Repeat(-1)
+
+	// playing forwards
+	game.Step(606)
+	expectTweenX(t, game, box, 0, duration, true)
+
+	// playing backwards
+	game.Step(9)
+	expectTweenX(t, game, box, 0, duration, true)
+}
+
+func TestTweenDelayKeepsTime(t *testing.T) {
+	game := gametest.New(t)
+	box := newBox(game, "box", 0, 0)
+
+	duration := 100 * time.Millisecond
+	delay := 25 * time.Millisecond
+	game.Tween(box, duration, nil).To(TweenProp.X, 10).Delay(delay).Repeat(-1)
+
+	game.Step(2)
+	if box.X != 0 {
+		t.Errorf("the tween started before its delay, moving the object to %g", box.X)
+	}
+
+	game.Step(604)
+	expectTweenX(t, game, box, delay, duration, false)
+}
@@ -1,23 +1,35 @@
 package main
 
 import (
+	"context"
 	"game/gamehandler"
 	game "game/src"
 	"time"
 )
 
-func Init(gameData *gamehandler.Game){
-	time.Sleep(1 * time.Second)
+func Init(ctx context.Context, gameData *gamehandler.Game){
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(1 * time.Second):
+	}
 
 	game.Init(gameData)
 
-	go GameLoop(gameData, 60, gamehandler.Update)
-	go GameLoop(gameData, 120, gamehandler.Draw)
+	gameData.Go(func(ctx context.Context) {
+		GameLoop(ctx, gameData, 60, gamehandler.Update)
+	})
+	gameData.Go(func(ctx context.Context) {
+		GameLoop(ctx, gameData, 120, gamehandler.Draw)
+	})
 
-	go GameLoop(gameData, 15, gamehandler.UpdateBasic)
-	go GameLoop(gameData, 30, gamehandler.UpdateSlow)
+	gameData.Go(func(ctx context.Context) {
+		GameLoop(ctx, gameData, 15, gamehandler.UpdateBasic)
+	})
+	gameData.Go(func(ctx context.Context) {
+		GameLoop(ctx, gameData, 30, gamehandler.UpdateSlow)
+	})
 
-	for _, objInit := range gamehandler.GameObjectInit {
-		objInit(gameData)
-	}
+	gameData.UseDefaultObjects()
+	gameData.InitObjects()
 }
@@ -0,0 +1,21 @@
+package lang
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// systemLanguage returns the first preferred language of the macOS user (example: "en-US")
+func systemLanguage() string {
+	// example output: (\n    "en-US",\n    "de-DE"\n)
+	out, err := exec.Command("defaults", "read", "-g", "AppleLanguages").Output()
+	if err != nil {
+		return ""
+	}
+
+	list := strings.Split(string(out), "\"")
+	if len(list) < 2 {
+		return ""
+	}
+	return list[1]
+}
@@ -0,0 +1,8 @@
+//go:build !windows && !darwin
+
+package lang
+
+// systemLanguage returns an empty string, because the environment variables are the only language setting on this OS
+func systemLanguage() string {
+	return ""
+}
@@ -0,0 +1,23 @@
+package lang
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var getUserDefaultLocaleName = syscall.NewLazyDLL("kernel32.dll").NewProc("GetUserDefaultLocaleName")
+
+// systemLanguage returns the locale of the windows user (example: "en-US")
+func systemLanguage() string {
+	if getUserDefaultLocaleName.Find() != nil {
+		return ""
+	}
+
+	// LOCALE_NAME_MAX_LENGTH
+	buf := make([]uint16, 85)
+	n, _, _ := getUserDefaultLocaleName.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if n == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf)
+}
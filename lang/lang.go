@@ -0,0 +1,389 @@
+// Package lang translates the text of the game, using a message catalog for each language
+//
+// catalogs are YAML (or JSON) files in ./assets/lang, named by their language code (example: ./assets/lang/en.yml)
+//
+//	title: My Game
+//	score: "Score: {score}"
+//	lives:
+//	  one: "{count} life"
+//	  other: "{count} lives"
+//	menu:
+//	  start: Start Game
+//
+// nested keys are joined with a "." (example: "menu.start")
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"game/gamehandler"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is the directory that language catalogs are loaded from
+var Dir = "./assets/lang"
+
+var catalogs map[string]map[string]message
+var language = "en"
+var fallback = "en"
+var onChange []func(code string)
+var langMU sync.RWMutex
+
+// message is a single translated message, with an optional list of plural forms
+type message struct {
+	text string
+	plural map[string]string
+}
+
+// Load loads the catalog of each language from the lang directory
+//
+// a catalog can be a .yml, .yaml or .json file, and is loaded with 'gamehandler.ReadFile' (so it can be embedded or replaced by a mod)
+//
+// languages that fail to load are skipped, and their errors are returned together
+//
+// example: lang.Load("en", "es", "fr", "de", "ja")
+func Load(codes ...string) error {
+	errs := []error{}
+	for _, code := range codes {
+		var buf []byte
+		var err error
+		for _, ext := range []string{".yml", ".yaml", ".json"} {
+			if buf, err = gamehandler.ReadFile(Dir + "/" + code + ext); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("lang %s: %w", code, err))
+			continue
+		}
+
+		if err := Parse(code, buf); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Parse parses a catalog (YAML or JSON), and adds its messages to a language
+//
+// messages with the same key will be replaced
+func Parse(code string, buf []byte) error {
+	data := map[string]any{}
+	if err := yaml.Unmarshal(buf, &data); err != nil {
+		return fmt.Errorf("lang %s: %w", code, err)
+	}
+
+	return Add(code, data)
+}
+
+// Add adds messages to a language
+//
+// messages with the same key will be replaced
+//
+// example: lang.Add("en", map[string]any{"title": "My Game", "lives": map[string]any{"one": "{count} life", "other": "{count} lives"}})
+func Add(code string, messages map[string]any) error {
+	list := map[string]message{}
+	if err := flatten(list, "", messages); err != nil {
+		return fmt.Errorf("lang %s: %w", code, err)
+	}
+
+	code = normalize(code)
+
+	langMU.Lock()
+	defer langMU.Unlock()
+
+	if catalogs == nil {
+		catalogs = map[string]map[string]message{}
+	}
+	if catalogs[code] == nil {
+		catalogs[code] = map[string]message{}
+	}
+	for key, msg := range list {
+		catalogs[code][key] = msg
+	}
+
+	return nil
+}
+
+// flatten adds the messages of a catalog to a list, joining nested keys with a "."
+func flatten(list map[string]message, prefix string, data map[string]any) error {
+	for key, val := range data {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if m, ok := stringMap(val); ok {
+			val = m
+		}
+
+		switch v := val.(type) {
+		case map[string]any:
+			if isPlural(v) {
+				msg := message{plural: map[string]string{}}
+				for form, text := range v {
+					msg.plural[form] = fmt.Sprint(text)
+				}
+				msg.text = msg.plural["other"]
+				list[key] = msg
+			}else if err := flatten(list, key, v); err != nil {
+				return err
+			}
+		case []any:
+			return fmt.Errorf("%s: lists are not supported", key)
+		case nil:
+			list[key] = message{}
+		default:
+			list[key] = message{text: fmt.Sprint(v)}
+		}
+	}
+
+	return nil
+}
+
+// isPlural returns true if a map is a list of plural forms
+//
+// a list of plural forms must have an 'other' form
+func isPlural(data map[string]any) bool {
+	if _, ok := data["other"]; !ok {
+		return false
+	}
+
+	for form, val := range data {
+		switch form {
+		case "zero", "one", "two", "few", "many", "other":
+		default:
+			return false
+		}
+		if _, ok := stringMap(val); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// stringMap converts a map to a map with string keys
+//
+// YAML maps with keys that are not strings (example: numbers) are decoded as a map[any]any
+func stringMap(val any) (map[string]any, bool) {
+	switch v := val.(type) {
+	case map[string]any:
+		return v, true
+	case map[any]any:
+		m := map[string]any{}
+		for key, val := range v {
+			m[fmt.Sprint(key)] = val
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// SetLanguage changes the current language, and runs the OnChange callbacks
+//
+// if a message is missing, the parent language (example: "pt" for "pt-BR") is checked, then the fallback language
+//
+// example: lang.SetLanguage("es")
+func SetLanguage(code string){
+	code = normalize(code)
+
+	langMU.Lock()
+	if language == code {
+		langMU.Unlock()
+		return
+	}
+	language = code
+	cbs := onChange
+	langMU.Unlock()
+
+	for _, cb := range cbs {
+		cb(code)
+	}
+}
+
+// Language returns the current language code
+func Language() string {
+	langMU.RLock()
+	defer langMU.RUnlock()
+
+	return language
+}
+
+// SetFallback sets the language that is used when a message is missing from the current language
+//
+// default: "en"
+func SetFallback(code string){
+	langMU.Lock()
+	defer langMU.Unlock()
+
+	fallback = normalize(code)
+}
+
+// Fallback returns the fallback language code
+func Fallback() string {
+	langMU.RLock()
+	defer langMU.RUnlock()
+
+	return fallback
+}
+
+// Languages returns the codes of the languages that have been loaded
+func Languages() []string {
+	langMU.RLock()
+	defer langMU.RUnlock()
+
+	list := []string{}
+	for code := range catalogs {
+		list = append(list, code)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Has returns true if a message exists in the current language (or the fallback language)
+func Has(key string) bool {
+	langMU.RLock()
+	defer langMU.RUnlock()
+
+	_, _, ok := find(key)
+	return ok
+}
+
+// OnChange adds a callback that runs when the language changes
+//
+// example: lang.OnChange(func(code string) { window.SetTitle(lang.T("title")) })
+func OnChange(cb func(code string)){
+	langMU.Lock()
+	defer langMU.Unlock()
+
+	onChange = append(onChange, cb)
+}
+
+// Detect returns the language of the system
+//
+// the LANGUAGE, LC_ALL, LC_MESSAGES and LANG environment variables are checked first,
+// then the language settings of the OS (on windows and macOS)
+//
+// returns an empty string if the language could not be found
+func Detect() string {
+	for _, env := range []string{"LANGUAGE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if code := localeCode(os.Getenv(env)); code != "" {
+			return code
+		}
+	}
+
+	return localeCode(systemLanguage())
+}
+
+// localeCode converts a system locale to a language code
+//
+// example: "en_US.UTF-8" or "en_US:de" -> "en-US"
+func localeCode(locale string) string {
+	locale = strings.SplitN(locale, ":", 2)[0]
+	locale = strings.SplitN(locale, ".", 2)[0]
+	locale = strings.SplitN(locale, "@", 2)[0]
+	if locale == "" || locale == "C" || locale == "POSIX" {
+		return ""
+	}
+	return normalize(locale)
+}
+
+// T returns the translated message for a key
+//
+// vars is a list of names and values to replace in the message
+//
+// if the message is missing from the current and fallback language, the key is returned
+//
+// example: lang.T("score", "score", 100) // "Score: 100"
+func T(key string, vars ...any) string {
+	langMU.RLock()
+	msg, _, ok := find(key)
+	langMU.RUnlock()
+
+	if !ok {
+		return key
+	}
+	return format(msg.text, vars)
+}
+
+// N returns the translated plural message for a key, using the plural rules of the language
+//
+// the count is also added to the vars as {count}
+//
+// example: lang.N("lives", 3) // "3 lives"
+func N(key string, count int, vars ...any) string {
+	langMU.RLock()
+	msg, code, ok := find(key)
+	langMU.RUnlock()
+
+	if !ok {
+		return key
+	}
+
+	text := msg.text
+	if msg.plural != nil {
+		form := PluralForm(code, count)
+		if t, ok := msg.plural["zero"]; ok && count == 0 {
+			text = t
+		}else if t, ok := msg.plural[form]; ok {
+			text = t
+		}
+	}
+
+	return format(text, append([]any{"count", count}, vars...))
+}
+
+// find returns a message from the current language, its parent language, or the fallback language
+//
+// also returns the language code the message was found in
+//
+// langMU should be locked before calling this method
+func find(key string) (message, string, bool) {
+	for _, code := range []string{language, parent(language), fallback} {
+		if code == "" {
+			continue
+		}
+		if msg, ok := catalogs[code][key]; ok {
+			return msg, code, true
+		}
+	}
+	return message{}, "", false
+}
+
+// format replaces the {name} vars in a message
+func format(text string, vars []any) string {
+	if len(vars) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	args := []string{}
+	for i := 0; i+1 < len(vars); i += 2 {
+		args = append(args, "{" + fmt.Sprint(vars[i]) + "}", fmt.Sprint(vars[i+1]))
+	}
+	return strings.NewReplacer(args...).Replace(text)
+}
+
+// normalize converts a language code to the format "en" or "pt-BR"
+func normalize(code string) string {
+	code = strings.ReplaceAll(strings.TrimSpace(code), "_", "-")
+	parts := strings.SplitN(code, "-", 2)
+	parts[0] = strings.ToLower(parts[0])
+	if len(parts) == 2 {
+		parts[1] = strings.ToUpper(parts[1])
+	}
+	return strings.Join(parts, "-")
+}
+
+// parent returns the base language of a regional language code (example: "pt" for "pt-BR")
+func parent(code string) string {
+	if i := strings.IndexByte(code, '-'); i != -1 {
+		return code[:i]
+	}
+	return ""
+}
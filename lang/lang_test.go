@@ -0,0 +1,59 @@
+package lang
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	err := Parse("test-parse", []byte(`
+title: My Game
+score: "Score: {score}"
+menu:
+  start: Start Game
+levels:
+  1: First Level
+  2: Second Level
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := Language()
+	SetLanguage("test-parse")
+	defer SetLanguage(old)
+
+	for key, text := range map[string]string{
+		"title": "My Game",
+		"menu.start": "Start Game",
+		"levels.1": "First Level",
+		"levels.2": "Second Level",
+	} {
+		if s := T(key); s != text {
+			t.Errorf("T(%q): expected %q, found %q", key, text, s)
+		}
+	}
+
+	if s := T("score", "score", 10); s != "Score: 10" {
+		t.Errorf("expected vars to be replaced, found %q", s)
+	}
+
+	if err := Parse("test-parse", []byte("list: [1, 2]")); err == nil {
+		t.Errorf("expected an error for a list")
+	}
+}
+
+func TestLocaleCode(t *testing.T) {
+	for locale, code := range map[string]string{
+		"en_US.UTF-8": "en-US",
+		"de:en": "de",
+		"pt_br": "pt-BR",
+		"sr_RS@latin": "sr-RS",
+		"C": "",
+		"POSIX.UTF-8": "",
+		"": "",
+	} {
+		if c := localeCode(locale); c != code {
+			t.Errorf("localeCode(%q): expected %q, found %q", locale, code, c)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package lang
+
+// PluralForm returns the plural form of a number for a language (zero, one, two, few, many, other)
+//
+// languages that are not known use the same rule as English (one, other)
+func PluralForm(code string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+
+	base := normalize(code)
+	if p := parent(base); p != "" {
+		base = p
+	}
+
+	switch base {
+	// no plural forms
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "lo", "my":
+		return "other"
+
+	// 0 and 1 are singular
+	case "fr", "hi", "bn", "fa", "pt":
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+
+	case "ru", "uk", "be", "sr", "hr", "bs":
+		if n % 10 == 1 && n % 100 != 11 {
+			return "one"
+		}
+		if n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 12 || n % 100 > 14) {
+			return "few"
+		}
+		return "many"
+
+	case "pl":
+		if n == 1 {
+			return "one"
+		}
+		if n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 12 || n % 100 > 14) {
+			return "few"
+		}
+		return "many"
+
+	case "cs", "sk":
+		if n == 1 {
+			return "one"
+		}
+		if n >= 2 && n <= 4 {
+			return "few"
+		}
+		return "other"
+
+	case "ar":
+		switch {
+		case n == 0:
+			return "zero"
+		case n == 1:
+			return "one"
+		case n == 2:
+			return "two"
+		case n % 100 >= 3 && n % 100 <= 10:
+			return "few"
+		case n % 100 >= 11:
+			return "many"
+		}
+		return "other"
+	}
+
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
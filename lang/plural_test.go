@@ -0,0 +1,65 @@
+package lang
+
+import (
+	"testing"
+)
+
+func TestPluralForm(t *testing.T) {
+	tests := []struct {
+		code string
+		forms map[int]string
+	}{
+		{"en", map[int]string{0: "other", 1: "one", 2: "other", 11: "other", 21: "other", -1: "one"}},
+		{"en-US", map[int]string{1: "one", 5: "other"}},
+		{"ja", map[int]string{0: "other", 1: "other", 2: "other"}},
+		{"fr", map[int]string{0: "one", 1: "one", 2: "other", 100: "other"}},
+		{"pt_BR", map[int]string{0: "one", 1: "one", 2: "other"}},
+		{"ru", map[int]string{1: "one", 2: "few", 4: "few", 5: "many", 11: "many", 12: "many", 21: "one", 22: "few", 25: "many", 111: "many", 112: "many"}},
+		{"pl", map[int]string{1: "one", 2: "few", 5: "many", 12: "many", 21: "many", 22: "few"}},
+		{"cs", map[int]string{1: "one", 2: "few", 4: "few", 5: "other", 22: "other"}},
+		{"ar", map[int]string{0: "zero", 1: "one", 2: "two", 3: "few", 10: "few", 11: "many", 99: "many", 100: "other", 102: "other", 103: "few"}},
+		{"xx", map[int]string{1: "one", 2: "other"}},
+	}
+
+	for _, test := range tests {
+		for n, form := range test.forms {
+			if f := PluralForm(test.code, n); f != form {
+				t.Errorf("PluralForm(%q, %d): expected %s, found %s", test.code, n, form, f)
+			}
+		}
+	}
+}
+
+func TestN(t *testing.T) {
+	err := Add("test-plural", map[string]any{
+		"apples": map[string]any{
+			"zero": "no apples",
+			"one": "{count} apple",
+			"other": "{count} apples",
+		},
+		"left": map[string]any{
+			"one": "{count} {item} left",
+			"other": "{count} {item}s left",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := Language()
+	SetLanguage("test-plural")
+	defer SetLanguage(old)
+
+	for count, text := range map[int]string{0: "no apples", 1: "1 apple", 2: "2 apples"} {
+		if s := N("apples", count); s != text {
+			t.Errorf("N(apples, %d): expected %q, found %q", count, text, s)
+		}
+	}
+
+	if s := N("left", 3, "item", "coin"); s != "3 coins left" {
+		t.Errorf("expected vars to be replaced, found %q", s)
+	}
+	if s := N("missing", 3); s != "missing" {
+		t.Errorf("expected a missing message to return its key, found %q", s)
+	}
+}
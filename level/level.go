@@ -0,0 +1,94 @@
+// Package level loads levels and object definitions from data files, so objects can be added without recompiling the game
+//
+// level files are written in YAML (or JSON), and objects can use lua scripts for their behavior
+package level
+
+import (
+	"fmt"
+	"game/gamehandler"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Level is a list of objects to add to the game
+type Level struct {
+	Name string `yaml:"Name"`
+
+	// Seed is an optional random seed for the level
+	//
+	// note: this is not used by the level package, and can be passed to the games random number generator
+	Seed int64 `yaml:"Seed"`
+
+	Objects []Object `yaml:"Objects"`
+}
+
+// Parse parses the YAML (or JSON) data of a level
+func Parse(buf []byte) (*Level, error) {
+	level := Level{}
+	if err := yaml.Unmarshal(buf, &level); err != nil {
+		return nil, err
+	}
+
+	for i := range level.Objects {
+		if err := level.Objects[i].Validate(); err != nil {
+			return nil, fmt.Errorf("level %q: %w", level.Name, err)
+		}
+	}
+
+	return &level, nil
+}
+
+// Read reads a level file
+//
+// the file is loaded with 'gamehandler.ReadFile', so it can be embedded
+//
+// example: level.Read("./levels/level1.yml")
+func Read(file string) (*Level, error) {
+	buf, err := gamehandler.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	level, err := Parse(buf)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	return level, nil
+}
+
+// Load reads a level file, and adds its objects to the game
+//
+// example: level.Load(game, "./levels/level1.yml")
+func Load(game *gamehandler.Game, file string) (*Level, error) {
+	level, err := Read(file)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := level.Spawn(game); err != nil {
+		return nil, err
+	}
+
+	return level, nil
+}
+
+// Spawn adds the objects of a level to the game
+//
+// if an object fails to spawn, the objects that were already added are removed
+func (level *Level) Spawn(game *gamehandler.Game) ([]*gamehandler.GameObject, error) {
+	list := []*gamehandler.GameObject{}
+
+	for _, def := range level.Objects {
+		object, err := Spawn(game, def)
+		if err != nil {
+			for _, obj := range list {
+				obj.Remove(game, nil)
+			}
+			return nil, fmt.Errorf("level %q: %w", level.Name, err)
+		}
+		list = append(list, object)
+	}
+
+	return list, nil
+}
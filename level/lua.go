@@ -0,0 +1,383 @@
+package level
+
+import (
+	"game/gamehandler"
+
+	lua "github.com/yuin/gopher-lua"
+	"gopkg.in/yaml.v3"
+)
+
+// scriptState is the lua state of a script attached to a single object
+type scriptState struct {
+	L *lua.LState
+	game *gamehandler.Game
+	object *gamehandler.GameObject
+	self lua.LValue
+
+	methods map[string]*lua.LFunction
+}
+
+// newScriptState creates a lua state with the game API
+//
+// only the base, table, string and math libraries are available, so scripts cannot access files
+func newScriptState(game *gamehandler.Game, object *gamehandler.GameObject) *scriptState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		fn lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	L.SetGlobal("dofile", lua.LNil)
+	L.SetGlobal("loadfile", lua.LNil)
+
+	// stop running scripts when the game shuts down
+	L.SetContext(game.Context())
+
+	state := scriptState{
+		L: L,
+		game: game,
+		object: object,
+	}
+
+	state.methods = map[string]*lua.LFunction{}
+	for name, fn := range state.objectMethods() {
+		state.methods[name] = L.NewFunction(fn)
+	}
+
+	mt := L.NewTypeMetatable("object")
+	L.SetField(mt, "__index", L.NewFunction(state.index))
+	L.SetField(mt, "__newindex", L.NewFunction(state.newIndex))
+	L.SetField(mt, "__eq", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LBool(checkObject(L, 1) == checkObject(L, 2)))
+		return 1
+	}))
+
+	L.SetGlobal("game", L.SetFuncs(L.NewTable(), state.gameMethods()))
+
+	state.self = state.wrap(object)
+
+	return &state
+}
+
+// wrap converts an object to a lua value
+func (state *scriptState) wrap(object *gamehandler.GameObject) lua.LValue {
+	if object == nil {
+		return lua.LNil
+	}
+
+	ud := state.L.NewUserData()
+	ud.Value = object
+	state.L.SetMetatable(ud, state.L.GetTypeMetatable("object"))
+	return ud
+}
+
+// wrapList converts a list of objects to a lua table
+func (state *scriptState) wrapList(list []*gamehandler.GameObject) *lua.LTable {
+	tb := state.L.CreateTable(len(list), 0)
+	for _, object := range list {
+		tb.Append(state.wrap(object))
+	}
+	return tb
+}
+
+// checkObject returns the object passed to a lua function
+func checkObject(L *lua.LState, n int) *gamehandler.GameObject {
+	ud := L.CheckUserData(n)
+	if object, ok := ud.Value.(*gamehandler.GameObject); ok {
+		return object
+	}
+	L.ArgError(n, "object expected")
+	return nil
+}
+
+// index gets a field or method of an object
+//
+// example: self.x
+func (state *scriptState) index(L *lua.LState) int {
+	object := checkObject(L, 1)
+	key := L.CheckString(2)
+
+	if fn, ok := state.methods[key]; ok {
+		L.Push(fn)
+		return 1
+	}
+
+	switch key {
+	case "x":
+		L.Push(lua.LNumber(object.X))
+	case "y":
+		L.Push(lua.LNumber(object.Y))
+	case "width":
+		L.Push(lua.LNumber(object.Width))
+	case "height":
+		L.Push(lua.LNumber(object.Height))
+	case "vel_x":
+		L.Push(lua.LNumber(object.VelX))
+	case "vel_y":
+		L.Push(lua.LNumber(object.VelY))
+	case "rotation":
+		L.Push(lua.LNumber(object.Rotation))
+	case "angular_vel":
+		L.Push(lua.LNumber(object.AngularVel))
+	case "scale_x":
+		L.Push(lua.LNumber(object.ScaleX))
+	case "scale_y":
+		L.Push(lua.LNumber(object.ScaleY))
+	case "border_x":
+		L.Push(lua.LNumber(object.OnBorderX))
+	case "border_y":
+		L.Push(lua.LNumber(object.OnBorderY))
+	case "hidden":
+		L.Push(lua.LBool(object.Hidden))
+	case "removed":
+		L.Push(lua.LBool(object.IsRemoved()))
+	case "type":
+		L.Push(lua.LString(object.Type()))
+	case "name":
+		L.Push(lua.LString(object.Name()))
+	case "id":
+		L.Push(lua.LString(object.ID()))
+	default:
+		L.Push(lua.LNil)
+	}
+	return 1
+}
+
+// newIndex sets a field of an object
+//
+// example: self.vel_x = 2
+func (state *scriptState) newIndex(L *lua.LState) int {
+	object := checkObject(L, 1)
+	key := L.CheckString(2)
+
+	if key == "hidden" {
+		object.Hidden = L.ToBool(3)
+		return 0
+	}
+
+	val := float32(L.CheckNumber(3))
+	switch key {
+	case "x":
+		object.X = val
+	case "y":
+		object.Y = val
+	case "width":
+		object.Width = val
+	case "height":
+		object.Height = val
+	case "vel_x":
+		object.VelX = val
+	case "vel_y":
+		object.VelY = val
+	case "rotation":
+		object.Rotation = val
+	case "angular_vel":
+		object.AngularVel = val
+	case "scale_x":
+		object.ScaleX = val
+	case "scale_y":
+		object.ScaleY = val
+	default:
+		L.RaiseError("cannot set field %q of an object", key)
+	}
+	return 0
+}
+
+// objectMethods returns the methods that can be called on an object
+//
+// example: self:move(1, 0)
+func (state *scriptState) objectMethods() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		// move(dx, dy) moves the object by an amount
+		"move": func(L *lua.LState) int {
+			object := checkObject(L, 1)
+			object.X += float32(L.CheckNumber(2))
+			object.Y += float32(L.CheckNumber(3))
+			return 0
+		},
+
+		// remove() removes the object from the game
+		"remove": func(L *lua.LState) int {
+			checkObject(L, 1).Remove(state.game, nil)
+			return 0
+		},
+
+		// tag(...) adds tags to the object
+		"tag": func(L *lua.LState) int {
+			checkObject(L, 1).AddTag(checkStrings(L, 2)...)
+			return 0
+		},
+
+		// untag(...) removes tags from the object
+		"untag": func(L *lua.LState) int {
+			checkObject(L, 1).RemoveTag(checkStrings(L, 2)...)
+			return 0
+		},
+
+		// has_tag(...) returns true if the object has all of the tags
+		"has_tag": func(L *lua.LState) int {
+			L.Push(lua.LBool(checkObject(L, 1).HasTag(checkStrings(L, 2)...)))
+			return 1
+		},
+
+		// collisions([type, [name]]) returns a list of the objects this object is colliding with
+		"collisions": func(L *lua.LState) int {
+			L.Push(state.wrapList(collisions(L)))
+			return 1
+		},
+
+		// colliding([type, [name]]) returns true if this object is colliding with anything
+		"colliding": func(L *lua.LState) int {
+			L.Push(lua.LBool(len(collisions(L)) != 0))
+			return 1
+		},
+
+		// distance(other) returns the distance to another object
+		"distance": func(L *lua.LState) int {
+			L.Push(lua.LNumber(checkObject(L, 1).GetDistance(checkObject(L, 2))))
+			return 1
+		},
+
+		// direction(other) returns the normalized direction (x, y) to another object
+		"direction": func(L *lua.LState) int {
+			dir := checkObject(L, 1).GetDirection(checkObject(L, 2))
+			L.Push(lua.LNumber(dir.DirX))
+			L.Push(lua.LNumber(dir.DirY))
+			return 2
+		},
+	}
+}
+
+// gameMethods returns the functions in the global 'game' table
+//
+// example: game.spawn({Type = "object", Name = "bullet", X = self.x, Y = self.y, Width = 1, Height = 1})
+func (state *scriptState) gameMethods() map[string]lua.LGFunction {
+	return map[string]lua.LGFunction{
+		// spawn(def) adds a new object to the game, using the same fields as an object in a level file
+		"spawn": func(L *lua.LState) int {
+			buf, err := yaml.Marshal(toGo(L.CheckTable(1)))
+			if err != nil {
+				L.RaiseError("%s", err)
+			}
+
+			def := Object{}
+			if err := yaml.Unmarshal(buf, &def); err != nil {
+				L.RaiseError("%s", err)
+			}
+
+			object, err := Spawn(state.game, def)
+			if err != nil {
+				L.RaiseError("%s", err)
+			}
+
+			L.Push(state.wrap(object))
+			return 1
+		},
+
+		// find(type, [name]) returns a list of objects
+		"find": func(L *lua.LState) int {
+			L.Push(state.wrapList(state.find(L)))
+			return 1
+		},
+
+		// first(type, [name]) returns the first matching object, or nil
+		"first": func(L *lua.LState) int {
+			if list := state.find(L); len(list) != 0 {
+				L.Push(state.wrap(list[0]))
+			}else{
+				L.Push(lua.LNil)
+			}
+			return 1
+		},
+
+		// tagged(...) returns a list of objects that have all of the tags
+		"tagged": func(L *lua.LState) int {
+			L.Push(state.wrapList(state.game.Query().Tag(checkStrings(L, 1)...).All()))
+			return 1
+		},
+
+		// size() returns the width and height of the game (after scaling)
+		"size": func(L *lua.LState) int {
+			L.Push(lua.LNumber(state.game.Size.Width))
+			L.Push(lua.LNumber(state.game.Size.Height))
+			return 2
+		},
+
+		// publish(event, [data]) publishes an event to the game
+		"publish": func(L *lua.LState) int {
+			state.game.Publish(L.CheckString(1), toGo(L.Get(2)))
+			return 0
+		},
+	}
+}
+
+// find returns the objects matching the type and optional name passed to a lua function
+func (state *scriptState) find(L *lua.LState) []*gamehandler.GameObject {
+	objType := L.CheckString(1)
+	if L.GetTop() >= 2 {
+		return state.game.Get(objType, L.CheckString(2))
+	}
+	return state.game.GetType(objType)
+}
+
+// collisions returns the objects colliding with the object passed to a lua function, with an optional type and name
+func collisions(L *lua.LState) []*gamehandler.GameObject {
+	object := checkObject(L, 1)
+	switch L.GetTop() {
+	case 1:
+		return object.IsColideingAny()
+	case 2:
+		return object.IsColideingType(L.CheckString(2))
+	default:
+		return object.IsColideingName(L.CheckString(2), L.CheckString(3))
+	}
+}
+
+// checkStrings returns the string arguments passed to a lua function, starting at n
+func checkStrings(L *lua.LState, n int) []string {
+	list := []string{}
+	for i := n; i <= L.GetTop(); i++ {
+		list = append(list, L.CheckString(i))
+	}
+	return list
+}
+
+// toGo converts a lua value to a go value
+//
+// tables with array items are converted to a slice, and other tables are converted to a map
+func toGo(val lua.LValue) any {
+	switch v := val.(type) {
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		return float64(v)
+	case lua.LString:
+		return string(v)
+	case *lua.LTable:
+		if v.MaxN() != 0 {
+			list := []any{}
+			for i := 1; i <= v.MaxN(); i++ {
+				list = append(list, toGo(v.RawGetInt(i)))
+			}
+			return list
+		}
+
+		m := map[string]any{}
+		v.ForEach(func(key lua.LValue, val lua.LValue) {
+			m[key.String()] = toGo(val)
+		})
+		return m
+	case *lua.LUserData:
+		return v.Value
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,281 @@
+package level
+
+import (
+	"fmt"
+	"game/enum/BorderMethod"
+	"game/enum/CollisionMethod"
+	"game/gamehandler"
+	"image/color"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"gopkg.in/yaml.v3"
+)
+
+// Object is the definition of an object, which can be loaded from level data or spawned by a script
+type Object struct {
+	// Def is the name of an object definition (see 'Define') to copy the default fields from
+	//
+	// any other fields set with the object will replace the fields of the definition
+	//
+	// Def is cleared once the fields of the definition have been copied (see 'Resolve')
+	Def string `yaml:"Def"`
+
+	// Type is the object type, and should be one of the ObjectTypes in config.yml
+	Type string `yaml:"Type"`
+	Name string `yaml:"Name"`
+
+	X float32 `yaml:"X"`
+	Y float32 `yaml:"Y"`
+	Width float32 `yaml:"Width"`
+	Height float32 `yaml:"Height"`
+
+	VelX float32 `yaml:"VelX"`
+	VelY float32 `yaml:"VelY"`
+	Rotation float32 `yaml:"Rotation"`
+	AngularVel float32 `yaml:"AngularVel"`
+
+	// FPS is the PreferredFPS of the object
+	FPS uint16 `yaml:"FPS"`
+
+	// Sprite is an image file to draw the object with
+	//
+	// example: ./assets/objects/enemy/enemy.png
+	Sprite string `yaml:"Sprite"`
+
+	// Color is used if the object does not have a sprite
+	//
+	// example: "#ff0000"
+	//
+	// default: white
+	Color string `yaml:"Color"`
+
+	// Shape is used if the object does not have a sprite (rect, circle)
+	//
+	// default: rect
+	Shape string `yaml:"Shape"`
+
+	// Collision is the name of a CollisionMethod (ghost, box, radius)
+	//
+	// default: ghost
+	Collision string `yaml:"Collision"`
+
+	// Border is the name of a BorderMethod (ignore, limit, hide, pushlimit, pushhide, bounce, teleport, removeobject)
+	//
+	// default: ignore
+	Border string `yaml:"Border"`
+
+	Tags []string `yaml:"Tags"`
+
+	// Script is a lua file that defines the Update, UpdateSlow, UpdateBasic and Draw methods of the object
+	//
+	// example: ./scripts/enemy.lua
+	Script string `yaml:"Script"`
+}
+
+var borderMethods = map[string]uint8{
+	"": BorderMethod.Ignore,
+	"ignore": BorderMethod.Ignore,
+	"limit": BorderMethod.Limit,
+	"hide": BorderMethod.Hide,
+	"pushlimit": BorderMethod.PushLimit,
+	"pushhide": BorderMethod.PushHide,
+	"bounce": BorderMethod.Bounce,
+	"teleport": BorderMethod.Teleport,
+	"removeobject": BorderMethod.RemoveObject,
+}
+
+var collisionMethods = map[string]uint8{
+	"": CollisionMethod.Ghost,
+	"ghost": CollisionMethod.Ghost,
+	"box": CollisionMethod.Box,
+	"radius": CollisionMethod.Radius,
+}
+
+// UnmarshalYAML decodes an object, starting with the fields of its definition if it has a 'Def'
+func (def *Object) UnmarshalYAML(node *yaml.Node) error {
+	type object Object
+
+	base := struct {
+		Def string `yaml:"Def"`
+	}{}
+	if err := node.Decode(&base); err != nil {
+		return err
+	}
+
+	res := object{}
+	if base.Def != "" {
+		d, ok := Definition(base.Def)
+		if !ok {
+			return fmt.Errorf("unknown object definition %q", base.Def)
+		}
+		res = object(d)
+	}
+
+	if err := node.Decode(&res); err != nil {
+		return err
+	}
+
+	res.Def = ""
+	*def = Object(res)
+	return nil
+}
+
+// Resolve returns a copy of the object, starting with the fields of its definition if it has a 'Def'
+//
+// any fields of the object that are not empty (or 0) will replace the fields of the definition
+//
+// note: level files set the fields of the definition when they are decoded, so a field can be set back to 0 in a level file
+func (def Object) Resolve() (Object, error) {
+	if def.Def == "" {
+		return def, nil
+	}
+
+	res, ok := Definition(def.Def)
+	if !ok {
+		return def, fmt.Errorf("unknown object definition %q", def.Def)
+	}
+
+	src := reflect.ValueOf(def)
+	dst := reflect.ValueOf(&res).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Field(i).IsZero() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+
+	res.Def = ""
+	return res, nil
+}
+
+// Validate returns an error if the object definition is invalid
+func (def *Object) Validate() error {
+	if def.Type == "" {
+		return fmt.Errorf("object %q is missing a Type", def.Name)
+	}
+	if _, ok := borderMethods[strings.ToLower(def.Border)]; !ok {
+		return fmt.Errorf("object %q has an unknown Border method %q", def.Name, def.Border)
+	}
+	if _, ok := collisionMethods[strings.ToLower(def.Collision)]; !ok {
+		return fmt.Errorf("object %q has an unknown Collision method %q", def.Name, def.Collision)
+	}
+	if shape := strings.ToLower(def.Shape); shape != "" && shape != "rect" && shape != "circle" {
+		return fmt.Errorf("object %q has an unknown Shape %q", def.Name, def.Shape)
+	}
+	if _, err := parseColor(def.Color); err != nil {
+		return fmt.Errorf("object %q: %w", def.Name, err)
+	}
+	return nil
+}
+
+// Spawn adds an object to the game from its definition
+//
+// if the object has a Def, the fields of that definition are used for any fields the object does not set
+//
+// if the object has a Script, the script is loaded and attached to the object
+//
+// example: level.Spawn(game, level.Object{Def: "enemy", X: 10})
+func Spawn(game *gamehandler.Game, def Object) (*gamehandler.GameObject, error) {
+	def, err := def.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res fyne.Resource
+	if def.Sprite != "" {
+		r, err := gamehandler.LoadResource(def.Sprite)
+		if err != nil {
+			return nil, fmt.Errorf("object %q: %w", def.Name, err)
+		}
+		res = r
+	}
+
+	var script *Script
+	if def.Script != "" {
+		s, err := NewScript(game, def.Script)
+		if err != nil {
+			return nil, err
+		}
+		script = s
+	}
+
+	object := game.Add(def.Type, def.Name, def.X, def.Y, def.Width, def.Height, func(game *gamehandler.Game) fyne.CanvasObject {
+		if res != nil {
+			return canvas.NewImageFromResource(res)
+		}
+
+		c, _ := parseColor(def.Color)
+		if strings.ToLower(def.Shape) == "circle" {
+			return canvas.NewCircle(c)
+		}
+		return gamehandler.NewRectImage(c)
+	})
+
+	object.VelX = def.VelX
+	object.VelY = def.VelY
+	object.Rotation = def.Rotation
+	object.AngularVel = def.AngularVel
+
+	if def.FPS != 0 {
+		object.PreferredFPS = def.FPS
+	}
+
+	object.BorderMethod = borderMethods[strings.ToLower(def.Border)]
+	object.CollisionMethod = collisionMethods[strings.ToLower(def.Collision)]
+
+	if len(def.Tags) != 0 {
+		object.AddTag(def.Tags...)
+	}
+
+	if script != nil {
+		if err := script.Attach(object); err != nil {
+			object.Remove(game, nil)
+			return nil, err
+		}
+	}
+
+	return object, nil
+}
+
+// SpawnDef adds an object to the game from a definition added with 'Define'
+//
+// example: level.SpawnDef(game, "enemy", 10, 0)
+func SpawnDef(game *gamehandler.Game, name string, x, y float32) (*gamehandler.GameObject, error) {
+	def, ok := Definition(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown object definition %q", name)
+	}
+
+	def.X = x
+	def.Y = y
+	return Spawn(game, def)
+}
+
+// parseColor parses a hex color (#rgb, #rrggbb, or #rrggbbaa)
+func parseColor(hex string) (color.Color, error) {
+	if hex == "" {
+		return color.White, nil
+	}
+
+	s := strings.TrimPrefix(hex, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+
+	n, err := strconv.ParseUint(s, 16, 32)
+	if len(s) != 8 || err != nil {
+		return nil, fmt.Errorf("invalid color %q", hex)
+	}
+
+	return color.NRGBA{uint8(n >> 24), uint8(n >> 16), uint8(n >> 8), uint8(n)}, nil
+}
@@ -0,0 +1,112 @@
+package level
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var definitions map[string]Object
+var levelFiles map[string]string
+var registryMU sync.Mutex
+
+// Define adds an object definition, which level files and scripts can use with 'Def'
+//
+// the definition is stored by its Name, and replaces any definition with the same name
+//
+// if the definition has a Def, the fields of that definition are copied into it before it is stored
+//
+// example: level.Define(level.Object{Type: "object", Name: "enemy", Width: 4, Height: 4, Script: "./scripts/enemy.lua"})
+func Define(def Object) error {
+	if def.Name == "" {
+		return fmt.Errorf("object definition is missing a Name")
+	}
+
+	def, err := def.Resolve()
+	if err != nil {
+		return err
+	}
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	if definitions == nil {
+		definitions = map[string]Object{}
+	}
+	definitions[def.Name] = def
+
+	return nil
+}
+
+// Undefine removes an object definition
+func Undefine(name string){
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	delete(definitions, name)
+}
+
+// Definition returns an object definition by name
+func Definition(name string) (Object, bool) {
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	def, ok := definitions[name]
+	if ok {
+		def.Tags = append([]string{}, def.Tags...)
+	}
+	return def, ok
+}
+
+// Definitions returns the names of all of the object definitions
+func Definitions() []string {
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	list := []string{}
+	for name := range definitions {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Register adds a level file to the list of levels
+//
+// a level with the same name will be replaced
+//
+// example: level.Register("level1", "./levels/level1.yml")
+func Register(name string, file string){
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	if levelFiles == nil {
+		levelFiles = map[string]string{}
+	}
+	levelFiles[name] = file
+}
+
+// File returns the file of a registered level
+func File(name string) (string, bool) {
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	file, ok := levelFiles[name]
+	return file, ok
+}
+
+// Names returns the names of all of the registered levels
+func Names() []string {
+	registryMU.Lock()
+	defer registryMU.Unlock()
+
+	list := []string{}
+	for name := range levelFiles {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,176 @@
+package level
+
+import (
+	"bytes"
+	"fmt"
+	"game/gamehandler"
+	"log"
+	"sync"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// Script is a compiled lua script, which can be attached to objects
+//
+// a script can define any of these functions, which run on the matching game loop:
+//
+//	function init(self) end
+//	function update(self, dt) end
+//	function update_slow(self, dt) end
+//	function update_basic(self, dt) end
+//	function draw(self, dt) end
+//
+// dt is the amount of game time (in seconds) that passes during each frame of the loop
+//
+// each object gets its own lua state, so global variables in a script are not shared between objects
+type Script struct {
+	File string
+
+	game *gamehandler.Game
+	proto *lua.FunctionProto
+}
+
+// ErrorHandler is called when a script callback fails
+//
+// the callback that failed will stop running for that object
+var ErrorHandler = func(object *gamehandler.GameObject, err error){
+	log.Println(err)
+}
+
+var scriptCache map[string]*lua.FunctionProto
+var scriptCacheMU sync.Mutex
+
+// scriptCallbacks is the list of lua functions that can be attached to an object, and the game loop they run on
+var scriptCallbacks = []struct {
+	name string
+	field func(object *gamehandler.GameObject) *func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)
+}{
+	{"update", func(object *gamehandler.GameObject) *func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) { return &object.Update }},
+	{"update_slow", func(object *gamehandler.GameObject) *func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) { return &object.UpdateSlow }},
+	{"update_basic", func(object *gamehandler.GameObject) *func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) { return &object.UpdateBasic }},
+	{"draw", func(object *gamehandler.GameObject) *func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) { return &object.Draw }},
+}
+
+// NewScript loads and compiles a lua script
+//
+// compiled scripts are cached, so a script file is only read once
+//
+// example: level.NewScript(game, "./scripts/enemy.lua")
+func NewScript(game *gamehandler.Game, file string) (*Script, error) {
+	scriptCacheMU.Lock()
+	proto, ok := scriptCache[file]
+	scriptCacheMU.Unlock()
+
+	if !ok {
+		buf, err := gamehandler.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk, err := parse.Parse(bytes.NewReader(buf), file)
+		if err != nil {
+			return nil, err
+		}
+
+		proto, err = lua.Compile(chunk, file)
+		if err != nil {
+			return nil, err
+		}
+
+		scriptCacheMU.Lock()
+		if scriptCache == nil {
+			scriptCache = map[string]*lua.FunctionProto{}
+		}
+		scriptCache[file] = proto
+		scriptCacheMU.Unlock()
+	}
+
+	return &Script{
+		File: file,
+		game: game,
+		proto: proto,
+	}, nil
+}
+
+// CheckScript returns an error if a lua script has a syntax error
+//
+// the script is not run, or added to the cache
+func CheckScript(buf []byte, file string) error {
+	chunk, err := parse.Parse(bytes.NewReader(buf), file)
+	if err != nil {
+		return err
+	}
+
+	_, err = lua.Compile(chunk, file)
+	return err
+}
+
+// AttachScript loads a lua script, and attaches it to an object
+//
+// example: level.AttachScript(game, object, "./scripts/enemy.lua")
+func AttachScript(game *gamehandler.Game, object *gamehandler.GameObject, file string) error {
+	script, err := NewScript(game, file)
+	if err != nil {
+		return err
+	}
+	return script.Attach(object)
+}
+
+// Attach runs a script for an object, and sets the objects Update, UpdateSlow, UpdateBasic and Draw methods to the functions the script defines
+//
+// methods that are not defined by the script are left unchanged
+func (script *Script) Attach(object *gamehandler.GameObject) error {
+	state := newScriptState(script.game, object)
+	L := state.L
+
+	L.Push(L.NewFunctionFromProto(script.proto))
+	if err := L.PCall(0, 0, nil); err != nil {
+		L.Close()
+		return err
+	}
+
+	if fn, ok := L.GetGlobal("init").(*lua.LFunction); ok {
+		if err := L.CallByParam(lua.P{Fn: fn, Protect: true}, state.self); err != nil {
+			L.Close()
+			return err
+		}
+	}
+
+	// the object was removed by its init function
+	if object.IsRemoved() {
+		L.Close()
+		return nil
+	}
+
+	for _, cb := range scriptCallbacks {
+		if fn, ok := L.GetGlobal(cb.name).(*lua.LFunction); ok {
+			*cb.field(object) = state.callback(script.File, cb.name, fn)
+		}
+	}
+
+	// close the lua state when the object is removed
+	object.OnRemove(func(game *gamehandler.Game, object *gamehandler.GameObject){
+		L.Close()
+	})
+
+	return nil
+}
+
+// callback creates an object method that calls a lua function
+//
+// if the function fails, the error is sent to the ErrorHandler, and the function stops running
+//
+// the function also stops running once the object has been removed
+func (state *scriptState) callback(file string, name string, fn *lua.LFunction) func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+	return func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		if fn == nil || state.object.IsRemoved() {
+			return
+		}
+
+		if err := state.L.CallByParam(lua.P{Fn: fn, Protect: true}, state.self, lua.LNumber(thread.Delta.Seconds())); err != nil {
+			fn = nil
+			ErrorHandler(state.object, fmt.Errorf("%s: %s: %w", file, name, err))
+		}
+	}
+}
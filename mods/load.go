@@ -0,0 +1,415 @@
+package mods
+
+import (
+	"fmt"
+	"game/gamehandler"
+	"game/level"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Conflict is an object, level or file that is provided by more than one mod
+type Conflict struct {
+	// Kind is the type of conflict (object, level, file)
+	Kind string
+
+	// Name is the name of the object or level, or the path of the file
+	Name string
+
+	// Mods is the list of mods that provide it, in load order
+	//
+	// the last mod in the list is the one that is used
+	//
+	// "game" is used for objects and levels that were defined by the game before the mods were loaded
+	Mods []string
+}
+
+func (conflict Conflict) String() string {
+	return fmt.Sprintf("%s %q is provided by %s (using %s)", conflict.Kind, conflict.Name, strings.Join(conflict.Mods, ", "), conflict.Mods[len(conflict.Mods)-1])
+}
+
+// Report is the result of loading the mods directory
+type Report struct {
+	// Mods is the list of mods that were loaded, in load order
+	Mods []*Mod
+
+	// Errors is the list of problems that stopped a mod from loading
+	Errors []error
+
+	// Conflicts is the list of objects, levels and files that more than one mod provides
+	Conflicts []Conflict
+}
+
+func (report *Report) String() string {
+	var s strings.Builder
+
+	ids := []string{}
+	for _, mod := range report.Mods {
+		ids = append(ids, mod.ID)
+	}
+	fmt.Fprintf(&s, "loaded %d mods: %s", len(report.Mods), strings.Join(ids, ", "))
+
+	for _, err := range report.Errors {
+		fmt.Fprintf(&s, "\nerror: %v", err)
+	}
+	for _, conflict := range report.Conflicts {
+		fmt.Fprintf(&s, "\nconflict: %v", conflict)
+	}
+
+	return s.String()
+}
+
+var loaded map[string]bool
+var loadedMU sync.Mutex
+
+// Load discovers the mods in a directory, and merges them into the game
+//
+// object definitions are added with 'level.Define', levels are added with 'level.Register',
+// and the assets and scripts folders of each mod are added with 'gamehandler.AddAssetOverlay'
+//
+// a mod that fails to load is skipped, along with any mods that depend on it
+//
+// note: this should be called before the game starts, and a mod will only be loaded once
+//
+// example: report := mods.Load("./mods")
+func Load(dir string) *Report {
+	report := Report{}
+
+	list, errs := Discover(dir)
+	report.Errors = append(report.Errors, errs...)
+
+	list, errs = Order(list)
+	report.Errors = append(report.Errors, errs...)
+
+	owners := map[string]map[string][]string{
+		"object": {},
+		"level": {},
+		"file": {},
+	}
+	for _, name := range level.Definitions() {
+		owners["object"][name] = []string{"game"}
+	}
+	for _, name := range level.Names() {
+		owners["level"][name] = []string{"game"}
+	}
+
+	failed := map[string]bool{}
+
+	loadedMU.Lock()
+	defer loadedMU.Unlock()
+
+	for _, mod := range list {
+		if loaded[mod.ID] {
+			continue
+		}
+
+		skip := false
+		for _, dep := range mod.Depends {
+			if failed[dep] {
+				report.Errors = append(report.Errors, fmt.Errorf("mod %s: dependency %s failed to load", mod.ID, dep))
+				skip = true
+				break
+			}
+		}
+		if skip {
+			failed[mod.ID] = true
+			continue
+		}
+
+		data, err := mod.read(&report)
+
+		// drop the owners of any definitions that could not be restored
+		defs := map[string]bool{}
+		for _, name := range level.Definitions() {
+			defs[name] = true
+		}
+		for name := range owners["object"] {
+			if !defs[name] {
+				delete(owners["object"], name)
+			}
+		}
+
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("mod %s: %w", mod.ID, err))
+			failed[mod.ID] = true
+			continue
+		}
+
+		gamehandler.AddAssetOverlay(overlayFS{mod.fsys})
+		for _, file := range data.files {
+			owners["file"][file] = append(owners["file"][file], mod.ID)
+		}
+
+		for _, def := range data.objects {
+			if err := level.Define(def); err != nil {
+				report.Errors = append(report.Errors, fmt.Errorf("mod %s: %w", mod.ID, err))
+				continue
+			}
+			owners["object"][def.Name] = append(owners["object"][def.Name], mod.ID)
+		}
+
+		for name, file := range data.levels {
+			level.Register(name, file)
+			owners["level"][name] = append(owners["level"][name], mod.ID)
+		}
+
+		if loaded == nil {
+			loaded = map[string]bool{}
+		}
+		loaded[mod.ID] = true
+
+		report.Mods = append(report.Mods, mod)
+	}
+
+	for _, kind := range []string{"object", "level", "file"} {
+		names := []string{}
+		for name, mods := range owners[kind] {
+			if len(mods) > 1 {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			report.Conflicts = append(report.Conflicts, Conflict{
+				Kind: kind,
+				Name: name,
+				Mods: owners[kind][name],
+			})
+		}
+	}
+
+	return &report
+}
+
+// modData is the validated content of a mod
+type modData struct {
+	objects []level.Object
+	levels map[string]string
+	files []string
+}
+
+// read reads and validates the objects, levels, scripts and assets of a mod
+//
+// the object definitions of the mod are added while it is being read, so other objects and levels can use them,
+// and the previous definitions are restored before this method returns
+//
+// any definitions that fail to be restored are added to the errors of the report
+func (mod *Mod) read(report *Report) (*modData, error) {
+	data := modData{
+		levels: map[string]string{},
+	}
+
+	restore := map[string]*level.Object{}
+	defer func(){
+		for name, old := range restore {
+			if old != nil {
+				if err := level.Define(*old); err != nil {
+					level.Undefine(name)
+					report.Errors = append(report.Errors, fmt.Errorf("mod %s: failed to restore object %q: %w", mod.ID, name, err))
+				}
+			}else{
+				level.Undefine(name)
+			}
+		}
+	}()
+
+	// files that can replace the files of the game or other mods
+	files := []string{}
+	err := fs.WalkDir(mod.fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if name != "." && strings.HasPrefix(entry.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		files = append(files, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range files {
+		if isOverlay(name) {
+			data.files = append(data.files, name)
+		}
+	}
+
+	// check scripts for syntax errors
+	for _, name := range files {
+		if path.Ext(name) != ".lua" {
+			continue
+		}
+		buf, err := fs.ReadFile(mod.fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := level.CheckScript(buf, "./" + name); err != nil {
+			return nil, err
+		}
+	}
+
+	// object definitions
+	defined := map[string]bool{}
+	for _, name := range dataFiles(files, "objects") {
+		buf, err := fs.ReadFile(mod.fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes, err := objectNodes(buf)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		for _, node := range nodes {
+			def := level.Object{}
+			if err := node.Decode(&def); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			if def.Name == "" {
+				return nil, fmt.Errorf("%s: object definition is missing a Name", name)
+			}
+			if defined[def.Name] {
+				return nil, fmt.Errorf("%s: object %q is defined more than once", name, def.Name)
+			}
+			defined[def.Name] = true
+
+			if err := def.Validate(); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			for _, file := range []string{def.Sprite, def.Script} {
+				if file != "" && !mod.exists(file) {
+					return nil, fmt.Errorf("%s: object %q uses the file %q, which does not exist", name, def.Name, file)
+				}
+			}
+
+			data.objects = append(data.objects, def)
+
+			// allow the objects and levels after this one to use it
+			if old, ok := level.Definition(def.Name); ok {
+				restore[def.Name] = &old
+			}else{
+				restore[def.Name] = nil
+			}
+			if err := level.Define(def); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	// levels
+	for _, name := range dataFiles(files, "levels") {
+		buf, err := fs.ReadFile(mod.fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		lvl, err := level.Parse(buf)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		for _, def := range lvl.Objects {
+			for _, file := range []string{def.Sprite, def.Script} {
+				if file != "" && !mod.exists(file) {
+					return nil, fmt.Errorf("%s: object %q uses the file %q, which does not exist", name, def.Name, file)
+				}
+			}
+		}
+
+		levelName := lvl.Name
+		if levelName == "" {
+			levelName = strings.TrimSuffix(path.Base(name), path.Ext(name))
+		}
+		if _, ok := data.levels[levelName]; ok {
+			return nil, fmt.Errorf("%s: level %q is defined more than once", name, levelName)
+		}
+		data.levels[levelName] = filepath.Join(mod.Dir, filepath.FromSlash(name))
+	}
+
+	return &data, nil
+}
+
+// exists returns true if a file exists in the assets or scripts folder of the mod, or can be loaded with 'gamehandler.ReadFile'
+func (mod *Mod) exists(file string) bool {
+	name := strings.TrimPrefix(path.Clean(filepath.ToSlash(file)), "/")
+	if _, err := fs.Stat(overlayFS{mod.fsys}, name); err == nil {
+		return true
+	}
+
+	_, err := gamehandler.ReadFile(file)
+	return err == nil
+}
+
+// overlayDirs is the list of folders in a mod that can replace the files of the game or other mods
+//
+// other files (like a README.md, LICENSE, or preview image) are not used by the game
+var overlayDirs = []string{"assets", "scripts"}
+
+// isOverlay returns true if a file is in one of the overlayDirs
+func isOverlay(name string) bool {
+	for _, dir := range overlayDirs {
+		if strings.HasPrefix(name, dir + "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// overlayFS is the file system of a mod, which only has the files in the overlayDirs
+type overlayFS struct {
+	fsys fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if !isOverlay(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return o.fsys.Open(name)
+}
+
+// dataFiles returns the YAML and JSON files in a folder of a mod
+func dataFiles(files []string, dir string) []string {
+	list := []string{}
+	for _, name := range files {
+		if !strings.HasPrefix(name, dir + "/") {
+			continue
+		}
+		switch path.Ext(name) {
+		case ".yml", ".yaml", ".json":
+			list = append(list, name)
+		}
+	}
+	return list
+}
+
+// objectNodes returns the object definitions in a file, which can have a single object definition, or a list of object definitions
+func objectNodes(buf []byte) ([]*yaml.Node, error) {
+	node := yaml.Node{}
+	if err := yaml.Unmarshal(buf, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+
+	if node.Content[0].Kind == yaml.SequenceNode {
+		return node.Content[0].Content, nil
+	}
+	return []*yaml.Node{node.Content[0]}, nil
+}
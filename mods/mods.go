@@ -0,0 +1,192 @@
+// Package mods loads data packs from a mods directory
+//
+// each mod is a folder with a mod.yml file, and can contain any of these folders:
+//
+//	objects/  object definitions (YAML or JSON), which level files and scripts can use with 'Def'
+//	levels/   level files
+//	scripts/  lua scripts, which can also replace the scripts of the game
+//	assets/   sprites and other assets, which can also replace the assets of the game
+//
+// other files in a mod (like a README.md or LICENSE) are ignored
+//
+// mods are loaded in order of their dependencies, and a mod loaded later replaces the objects, levels and files of a mod loaded earlier
+package mods
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is the mod.yml file of a mod
+type Manifest struct {
+	// ID is the unique name of the mod, which other mods use to depend on it
+	//
+	// default: the name of the mod folder
+	ID string `yaml:"ID"`
+
+	Name string `yaml:"Name"`
+	Version string `yaml:"Version"`
+	Description string `yaml:"Description"`
+	Author string `yaml:"Author"`
+
+	// Depends is a list of mod IDs that need to be loaded before this mod
+	Depends []string `yaml:"Depends"`
+
+	// Disabled stops the mod from loading
+	Disabled bool `yaml:"Disabled"`
+}
+
+// Mod is a mod found in the mods directory
+type Mod struct {
+	Manifest
+
+	// Dir is the folder of the mod
+	Dir string
+
+	fsys fs.FS
+}
+
+// Discover finds the mods in a directory, and reads their mod.yml files
+//
+// mods that are disabled are not returned
+//
+// returns the list of mods (sorted by ID), and a list of errors for any folders that are not valid mods
+func Discover(dir string) ([]*Mod, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{err}
+	}
+
+	list := []*Mod{}
+	errs := []error{}
+	ids := map[string]string{}
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		modDir := filepath.Join(dir, entry.Name())
+		mod, err := readMod(modDir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("mod %s: %w", entry.Name(), err))
+			continue
+		}
+
+		if mod.Disabled {
+			continue
+		}
+
+		if other, ok := ids[mod.ID]; ok {
+			errs = append(errs, fmt.Errorf("mod %s: the ID %q is already used by %s", entry.Name(), mod.ID, other))
+			continue
+		}
+		ids[mod.ID] = modDir
+
+		list = append(list, mod)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+
+	return list, errs
+}
+
+// readMod reads the mod.yml file of a mod
+func readMod(dir string) (*Mod, error) {
+	buf, err := os.ReadFile(filepath.Join(dir, "mod.yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	mod := Mod{
+		Dir: dir,
+		fsys: os.DirFS(dir),
+	}
+	if err := yaml.Unmarshal(buf, &mod.Manifest); err != nil {
+		return nil, fmt.Errorf("mod.yml: %w", err)
+	}
+
+	if mod.ID == "" {
+		mod.ID = filepath.Base(dir)
+	}
+	if strings.ContainsAny(mod.ID, " \t\r\n/\\") {
+		return nil, fmt.Errorf("mod.yml: invalid ID %q", mod.ID)
+	}
+	if mod.Name == "" {
+		mod.Name = mod.ID
+	}
+
+	return &mod, nil
+}
+
+// Order sorts a list of mods so each mod comes after its dependencies
+//
+// mods with the same dependencies are sorted by ID
+//
+// mods that are missing a dependency, or are part of a dependency loop, are removed from the list
+func Order(mods []*Mod) ([]*Mod, []error) {
+	remaining := append([]*Mod{}, mods...)
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].ID < remaining[j].ID
+	})
+
+	list := []*Mod{}
+	loaded := map[string]bool{}
+
+	for progress := true; progress; {
+		progress = false
+		for i := 0; i < len(remaining); i++ {
+			mod := remaining[i]
+
+			ready := true
+			for _, dep := range mod.Depends {
+				if !loaded[dep] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				list = append(list, mod)
+				loaded[mod.ID] = true
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				progress = true
+				break
+			}
+		}
+	}
+
+	found := map[string]bool{}
+	for _, mod := range mods {
+		found[mod.ID] = true
+	}
+
+	errs := []error{}
+	for _, mod := range remaining {
+		missing := []string{}
+		for _, dep := range mod.Depends {
+			if !found[dep] {
+				missing = append(missing, dep)
+			}
+		}
+
+		if len(missing) != 0 {
+			errs = append(errs, fmt.Errorf("mod %s: missing dependencies: %s", mod.ID, strings.Join(missing, ", ")))
+		}else{
+			errs = append(errs, fmt.Errorf("mod %s: dependencies could not be loaded (or depend on each other)", mod.ID))
+		}
+	}
+
+	return list, errs
+}
@@ -0,0 +1,152 @@
+package pathfind
+
+import (
+	"game/enum/Loop"
+	"game/gamehandler"
+	"math"
+)
+
+// Follower steers an object along a path to a target
+//
+// the path is recomputed on the UpdateBasic loop, and the velocity of the object is set on the Update loop
+type Follower struct {
+	// Speed is the velocity the object will move at
+	Speed float32
+
+	// StopDistance is how close the object needs to get to the target before it stops moving
+	StopDistance float32
+
+	// Path is the current list of points the object is moving through
+	Path []Point
+
+	object *gamehandler.GameObject
+	target *gamehandler.GameObject
+	targetPos *Point
+	grid *Grid
+
+	remove []func()
+}
+
+// Follow makes an object chase a target object, moving around any solid cells in the grid
+//
+// if the target is removed from the game, the object will stop following it
+//
+// example: pathfind.Follow(enemy, player, grid, 3)
+func Follow(object *gamehandler.GameObject, target *gamehandler.GameObject, grid *Grid, speed float32) *Follower {
+	follower := newFollower(object, grid, speed)
+	follower.target = target
+	return follower
+}
+
+// FollowTo makes an object move to a position, moving around any solid cells in the grid
+func FollowTo(object *gamehandler.GameObject, x, y float32, grid *Grid, speed float32) *Follower {
+	follower := newFollower(object, grid, speed)
+	follower.targetPos = &Point{X: x, Y: y}
+	return follower
+}
+
+func newFollower(object *gamehandler.GameObject, grid *Grid, speed float32) *Follower {
+	follower := Follower{
+		Speed: speed,
+		StopDistance: grid.CellSize / 2,
+		object: object,
+		grid: grid,
+	}
+
+	follower.remove = append(follower.remove,
+		object.AddUpdate(Loop.UpdateBasic, follower.updatePath),
+		object.AddUpdate(Loop.Update, follower.steer),
+	)
+
+	return &follower
+}
+
+// Stop stops the object from following its target, and sets its velocity to 0
+func (follower *Follower) Stop(){
+	for _, remove := range follower.remove {
+		remove()
+	}
+	follower.remove = nil
+
+	follower.Path = nil
+	follower.object.VelX = 0
+	follower.object.VelY = 0
+}
+
+// SetGrid changes the grid used for finding a path
+//
+// this is useful if the level changes
+func (follower *Follower) SetGrid(grid *Grid){
+	follower.grid = grid
+	follower.Path = nil
+}
+
+// updatePath recomputes the path to the target
+func (follower *Follower) updatePath(game *gamehandler.Game, thread *gamehandler.ThreadInfo){
+	if follower.lostTarget() {
+		return
+	}
+
+	x, y := follower.targetPosition()
+	objX, objY := follower.object.WorldPos()
+	follower.Path = follower.grid.FindPath(objX, objY, x, y)
+}
+
+// steer sets the velocity of the object to move towards the next point in the path
+func (follower *Follower) steer(game *gamehandler.Game, thread *gamehandler.ThreadInfo){
+	if follower.lostTarget() {
+		return
+	}
+
+	object := follower.object
+	objX, objY := object.WorldPos()
+
+	x, y := follower.targetPosition()
+	if distance(objX, objY, x, y) <= follower.StopDistance {
+		object.VelX = 0
+		object.VelY = 0
+		return
+	}
+
+	// skip points that have already been reached
+	reach := follower.grid.CellSize / 4
+	for len(follower.Path) > 1 && distance(objX, objY, follower.Path[0].X, follower.Path[0].Y) <= reach {
+		follower.Path = follower.Path[1:]
+	}
+
+	if len(follower.Path) == 0 {
+		object.VelX = 0
+		object.VelY = 0
+		return
+	}
+
+	diffX := follower.Path[0].X - objX
+	diffY := follower.Path[0].Y - objY
+	dist := distance(objX, objY, follower.Path[0].X, follower.Path[0].Y)
+	if dist == 0 {
+		return
+	}
+
+	object.VelX = diffX / dist * follower.Speed
+	object.VelY = diffY / dist * follower.Speed
+}
+
+// lostTarget stops following if the target object was removed from the game
+func (follower *Follower) lostTarget() bool {
+	if follower.target != nil && follower.target.IsRemoved() {
+		follower.Stop()
+		follower.target = nil
+	}
+	return follower.target == nil && follower.targetPos == nil
+}
+
+func (follower *Follower) targetPosition() (float32, float32) {
+	if follower.target != nil {
+		return follower.target.WorldPos()
+	}
+	return follower.targetPos.X, follower.targetPos.Y
+}
+
+func distance(x1, y1, x2, y2 float32) float32 {
+	return float32(math.Sqrt(math.Pow(float64(x1 - x2), 2) + math.Pow(float64(y1 - y2), 2)))
+}
@@ -0,0 +1,334 @@
+package pathfind
+
+import (
+	"container/heap"
+	"game/gamehandler"
+	"math"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Grid is a map of solid and open cells used for finding paths around obstacles
+type Grid struct {
+	// X is the left edge of the grid in game units
+	X float32
+
+	// Y is the top edge of the grid in game units
+	Y float32
+
+	// CellSize is the width and height of each cell in game units
+	CellSize float32
+
+	// Cols is the number of cells in the x axis
+	Cols int
+
+	// Rows is the number of cells in the y axis
+	Rows int
+
+	solid []bool
+	mu sync.RWMutex
+}
+
+// Point is a position in game units
+type Point struct {
+	X float32
+	Y float32
+}
+
+// NewGrid creates a new empty grid that covers an area of the game
+func NewGrid(x, y, width, height, cellSize float32) *Grid {
+	if cellSize <= 0 {
+		cellSize = 1
+	}
+
+	cols := int(math.Ceil(float64(width / cellSize)))
+	rows := int(math.Ceil(float64(height / cellSize)))
+	if cols < 1 {
+		cols = 1
+	}
+	if rows < 1 {
+		rows = 1
+	}
+
+	return &Grid{
+		X: x,
+		Y: y,
+		CellSize: cellSize,
+		Cols: cols,
+		Rows: rows,
+		solid: make([]bool, cols * rows),
+	}
+}
+
+// NewGameGrid creates a new empty grid that covers the visible game area
+//
+// note: the grid will not resize with the window
+func NewGameGrid(game *gamehandler.Game, cellSize float32) *Grid {
+	return NewGrid(-game.Size.Width, -game.Size.Height, game.Size.Width * 2, game.Size.Height * 2, cellSize)
+}
+
+// NewTileGrid creates a new grid from a tilemap
+//
+// each string is a row of tiles, where a space or '.' is open, and any other character is solid
+//
+// example: NewTileGrid(-50, -50, 10, []string{"..........", "..####....", ".........."})
+func NewTileGrid(x, y, cellSize float32, tiles []string) *Grid {
+	cols := 0
+	for _, row := range tiles {
+		if n := utf8.RuneCountInString(row); n > cols {
+			cols = n
+		}
+	}
+
+	grid := NewGrid(x, y, float32(cols) * cellSize, float32(len(tiles)) * cellSize, cellSize)
+
+	for r, row := range tiles {
+		for c, tile := range []rune(row) {
+			if !strings.ContainsRune(" .", tile) {
+				grid.solid[r * grid.Cols + c] = true
+			}
+		}
+	}
+
+	return grid
+}
+
+// SetSolid sets if a cell is blocked
+func (grid *Grid) SetSolid(col, row int, solid bool){
+	grid.mu.Lock()
+	defer grid.mu.Unlock()
+
+	if grid.inBounds(col, row) {
+		grid.solid[row * grid.Cols + col] = solid
+	}
+}
+
+// IsSolid returns true if a cell is blocked
+//
+// cells outside the grid are always solid
+func (grid *Grid) IsSolid(col, row int) bool {
+	grid.mu.RLock()
+	defer grid.mu.RUnlock()
+
+	return grid.isSolid(col, row)
+}
+
+// Clear sets every cell in the grid to open
+func (grid *Grid) Clear(){
+	grid.mu.Lock()
+	defer grid.mu.Unlock()
+
+	for i := range grid.solid {
+		grid.solid[i] = false
+	}
+}
+
+// AddObject sets every cell the object covers to solid
+//
+// padding: extra space around the object to keep clear (use the size of the object that needs to move around it)
+func (grid *Grid) AddObject(object *gamehandler.GameObject, padding float32){
+	grid.mu.Lock()
+	defer grid.mu.Unlock()
+
+	grid.addObject(object, padding)
+}
+
+// AddObjects sets every cell a list of objects cover to solid
+//
+// padding: extra space around the objects to keep clear (use the size of the object that needs to move around them)
+func (grid *Grid) AddObjects(objects []*gamehandler.GameObject, padding float32){
+	grid.mu.Lock()
+	defer grid.mu.Unlock()
+
+	for _, object := range objects {
+		grid.addObject(object, padding)
+	}
+}
+
+// AddType sets every cell covered by an object type to solid
+//
+// this is useful for building a grid from static colliders, like walls
+func (grid *Grid) AddType(game *gamehandler.Game, objType string, padding float32){
+	grid.AddObjects(game.GetType(objType), padding)
+}
+
+// Cell returns the column and row of a position in the grid
+//
+// returns false if the position is outside the grid
+func (grid *Grid) Cell(x, y float32) (col int, row int, ok bool) {
+	col = int(math.Floor(float64((x - grid.X) / grid.CellSize)))
+	row = int(math.Floor(float64((y - grid.Y) / grid.CellSize)))
+	return col, row, grid.inBounds(col, row)
+}
+
+// CellCenter returns the position of the center of a cell
+func (grid *Grid) CellCenter(col, row int) Point {
+	return Point{
+		X: grid.X + (float32(col) + 0.5) * grid.CellSize,
+		Y: grid.Y + (float32(row) + 0.5) * grid.CellSize,
+	}
+}
+
+// FindPath uses A* to find the shortest path between 2 positions, avoiding solid cells
+//
+// the path includes the end position, but not the start position
+//
+// returns nil if there is no path
+func (grid *Grid) FindPath(fromX, fromY, toX, toY float32) []Point {
+	grid.mu.RLock()
+	defer grid.mu.RUnlock()
+
+	startCol, startRow, ok := grid.Cell(fromX, fromY)
+	if !ok {
+		return nil
+	}
+
+	endCol, endRow, ok := grid.Cell(toX, toY)
+	if !ok || grid.isSolid(endCol, endRow) {
+		return nil
+	}
+
+	start := startRow * grid.Cols + startCol
+	end := endRow * grid.Cols + endCol
+
+	if start == end {
+		return []Point{{X: toX, Y: toY}}
+	}
+
+	cost := map[int]float64{start: 0}
+	from := map[int]int{}
+	closed := map[int]bool{}
+
+	open := &nodeHeap{}
+	heap.Push(open, &node{cell: start, score: grid.heuristic(start, end)})
+
+	for open.Len() != 0 {
+		current := heap.Pop(open).(*node)
+		if current.cell == end {
+			return grid.buildPath(from, start, end, toX, toY)
+		}
+
+		if closed[current.cell] {
+			continue
+		}
+		closed[current.cell] = true
+
+		col := current.cell % grid.Cols
+		row := current.cell / grid.Cols
+
+		for _, dir := range directions {
+			c := col + dir[0]
+			r := row + dir[1]
+			if grid.isSolid(c, r) {
+				continue
+			}
+
+			// prevent cutting corners around solid cells
+			if dir[0] != 0 && dir[1] != 0 && (grid.isSolid(col + dir[0], row) || grid.isSolid(col, row + dir[1])) {
+				continue
+			}
+
+			next := r * grid.Cols + c
+			if closed[next] {
+				continue
+			}
+
+			step := float64(1)
+			if dir[0] != 0 && dir[1] != 0 {
+				step = math.Sqrt2
+			}
+
+			newCost := cost[current.cell] + step
+			if oldCost, ok := cost[next]; ok && newCost >= oldCost {
+				continue
+			}
+
+			cost[next] = newCost
+			from[next] = current.cell
+			heap.Push(open, &node{cell: next, score: newCost + grid.heuristic(next, end)})
+		}
+	}
+
+	return nil
+}
+
+var directions = [8][2]int{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+}
+
+func (grid *Grid) inBounds(col, row int) bool {
+	return col >= 0 && row >= 0 && col < grid.Cols && row < grid.Rows
+}
+
+func (grid *Grid) isSolid(col, row int) bool {
+	if !grid.inBounds(col, row) {
+		return true
+	}
+	return grid.solid[row * grid.Cols + col]
+}
+
+func (grid *Grid) addObject(object *gamehandler.GameObject, padding float32){
+	x, y := object.WorldPos()
+	minCol, minRow, _ := grid.Cell(x - object.Width - padding, y - object.Height - padding)
+	maxCol, maxRow, _ := grid.Cell(x + object.Width + padding, y + object.Height + padding)
+
+	for r := minRow; r <= maxRow; r++ {
+		for c := minCol; c <= maxCol; c++ {
+			if grid.inBounds(c, r) {
+				grid.solid[r * grid.Cols + c] = true
+			}
+		}
+	}
+}
+
+// heuristic returns the octile distance between 2 cells
+func (grid *Grid) heuristic(a, b int) float64 {
+	dx := math.Abs(float64(a % grid.Cols - b % grid.Cols))
+	dy := math.Abs(float64(a / grid.Cols - b / grid.Cols))
+	return (dx + dy) + (math.Sqrt2 - 2) * math.Min(dx, dy)
+}
+
+// buildPath walks back through the path, and removes any points that continue in the same direction
+func (grid *Grid) buildPath(from map[int]int, start, end int, toX, toY float32) []Point {
+	cells := []int{end}
+	for cell := end; cell != start; {
+		cell = from[cell]
+		cells = append(cells, cell)
+	}
+
+	path := []Point{}
+	for i := len(cells) - 2; i > 0; i-- {
+		prev := cells[i+1]
+		cell := cells[i]
+		next := cells[i-1]
+
+		// skip points in a straight line
+		if cell - prev == next - cell {
+			continue
+		}
+
+		path = append(path, grid.CellCenter(cell % grid.Cols, cell / grid.Cols))
+	}
+
+	return append(path, Point{X: toX, Y: toY})
+}
+
+type node struct {
+	cell int
+	score float64
+}
+
+type nodeHeap []*node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool { return h[i].score < h[j].score }
+func (h nodeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x any) { *h = append(*h, x.(*node)) }
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,154 @@
+package pathfind
+
+import (
+	"testing"
+)
+
+// walk checks that a path only moves through open cells, and returns its length
+func walk(t *testing.T, grid *Grid, fromX, fromY float32, path []Point) float32 {
+	t.Helper()
+
+	length := float32(0)
+	x, y := fromX, fromY
+	for _, p := range path {
+		// check a few points along each straight line of the path
+		for i := 1; i <= 10; i++ {
+			px := x + (p.X - x) * float32(i) / 10
+			py := y + (p.Y - y) * float32(i) / 10
+			if col, row, ok := grid.Cell(px, py); !ok || grid.IsSolid(col, row) {
+				t.Fatalf("path goes through a solid cell at %g, %g: %v", px, py, path)
+			}
+		}
+
+		length += distance(x, y, p.X, p.Y)
+		x, y = p.X, p.Y
+	}
+	return length
+}
+
+func TestFindPathOpen(t *testing.T) {
+	grid := NewGrid(0, 0, 100, 100, 10)
+
+	// a straight line should only have the end point
+	path := grid.FindPath(5, 5, 95, 5)
+	if len(path) != 1 || path[0] != (Point{95, 5}) {
+		t.Errorf("expected a straight path, found %v", path)
+	}
+
+	path = grid.FindPath(5, 5, 95, 95)
+	if path == nil {
+		t.Fatal("no path found on an empty grid")
+	}
+	if length := walk(t, grid, 5, 5, path); length > 128 {
+		t.Errorf("expected a diagonal path of about 127 units, found %g", length)
+	}
+
+	// the start and end are in the same cell
+	if path := grid.FindPath(1, 1, 8, 8); len(path) != 1 || path[0] != (Point{8, 8}) {
+		t.Errorf("expected a path to the end position, found %v", path)
+	}
+}
+
+func TestFindPathAroundWall(t *testing.T) {
+	grid := NewTileGrid(0, 0, 10, []string{
+		"..........",
+		"..........",
+		"....#.....",
+		"....#.....",
+		"....#.....",
+		"....#.....",
+		"..........",
+	})
+
+	path := grid.FindPath(15, 45, 75, 45)
+	if path == nil {
+		t.Fatal("no path found around the wall")
+	}
+	walk(t, grid, 15, 45, path)
+
+	if end := path[len(path)-1]; end != (Point{75, 45}) {
+		t.Errorf("path ends at %v", end)
+	}
+}
+
+func TestTileGridRunes(t *testing.T) {
+	grid := NewTileGrid(0, 0, 10, []string{
+		"..█.",
+		"█...",
+	})
+
+	if grid.Cols != 4 {
+		t.Errorf("expected 4 columns, found %d", grid.Cols)
+	}
+
+	for r, row := range [][]bool{{false, false, true, false}, {true, false, false, false}} {
+		for c, solid := range row {
+			if grid.IsSolid(c, r) != solid {
+				t.Errorf("expected cell %d,%d solid to be %v", c, r, solid)
+			}
+		}
+	}
+}
+
+func TestFindPathBlocked(t *testing.T) {
+	grid := NewTileGrid(0, 0, 10, []string{
+		"....#.....",
+		"....#.....",
+		"....#.....",
+	})
+
+	if path := grid.FindPath(5, 5, 95, 5); path != nil {
+		t.Errorf("found a path through a wall: %v", path)
+	}
+
+	// the end is solid
+	if path := grid.FindPath(5, 5, 45, 5); path != nil {
+		t.Errorf("found a path to a solid cell: %v", path)
+	}
+
+	// the start is outside the grid
+	if path := grid.FindPath(-5, 5, 15, 5); path != nil {
+		t.Errorf("found a path from outside the grid: %v", path)
+	}
+}
+
+func TestFindPathCorners(t *testing.T) {
+	// the only diagonal gap is between 2 solid cells, which the path should not squeeze through
+	grid := NewTileGrid(0, 0, 10, []string{
+		".#",
+		"#.",
+	})
+
+	if path := grid.FindPath(5, 5, 15, 15); path != nil {
+		t.Errorf("path cut a corner: %v", path)
+	}
+}
+
+func TestCells(t *testing.T) {
+	grid := NewGrid(-50, -50, 100, 100, 10)
+
+	col, row, ok := grid.Cell(-45, 12)
+	if !ok || col != 0 || row != 6 {
+		t.Errorf("expected cell 0, 6, found %d, %d (%v)", col, row, ok)
+	}
+	if _, _, ok := grid.Cell(51, 0); ok {
+		t.Errorf("position outside the grid returned a cell")
+	}
+
+	if p := grid.CellCenter(0, 6); p != (Point{-45, 15}) {
+		t.Errorf("expected the center of cell 0, 6 to be -45, 15, found %v", p)
+	}
+
+	grid.SetSolid(3, 4, true)
+	if !grid.IsSolid(3, 4) {
+		t.Errorf("cell 3, 4 is not solid")
+	}
+	if !grid.IsSolid(-1, 0) {
+		t.Errorf("cells outside the grid should be solid")
+	}
+
+	grid.Clear()
+	if grid.IsSolid(3, 4) {
+		t.Errorf("cell 3, 4 is still solid after Clear")
+	}
+}
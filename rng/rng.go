@@ -0,0 +1,334 @@
+package rng
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+)
+
+// RNG is a seeded random number generator
+//
+// the same seed will always produce the same numbers, which allows levels to have a pattern players can learn
+//
+// an optional noise source can randomly replace some of the numbers,
+// which makes that pattern harder to learn, without fully removing it
+type RNG struct {
+	state [4]uint64
+	noise [4]uint64
+	noiseAmount float64
+
+	spare float64
+	hasSpare bool
+
+	mu sync.Mutex
+}
+
+// State is a copy of the internal state of an RNG
+//
+// it can be saved (for example, as JSON in a save file) and restored later to continue the same sequence of numbers
+type State struct {
+	Main [4]uint64 `json:"main"`
+	Noise [4]uint64 `json:"noise"`
+	NoiseAmount float64 `json:"noiseAmount"`
+	Spare float64 `json:"spare"`
+	HasSpare bool `json:"hasSpare"`
+}
+
+// New creates a new random number generator from a seed
+func New(seed int64) *RNG {
+	r := RNG{}
+	r.state = seedState(seed)
+	return &r
+}
+
+// Seed resets the random number generator to the start of a new seed
+//
+// the noise source is not changed
+func (r *RNG) Seed(seed int64){
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state = seedState(seed)
+	r.hasSpare = false
+}
+
+// SetNoise enables the inconsistency noise source
+//
+// seed: the seed for the noise (use the same seed to reproduce the same inconsistencies)
+//
+// amount: the chance (between 0 and 1) that each number will be replaced by a number from the noise source
+//
+// an amount of 0 disables the noise
+func (r *RNG) SetNoise(seed int64, amount float64){
+	if amount < 0 {
+		amount = 0
+	}else if amount > 1 {
+		amount = 1
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.noise = seedState(seed)
+	r.noiseAmount = amount
+}
+
+// State returns a copy of the internal state, which can be restored later with 'Restore'
+func (r *RNG) State() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return State{
+		Main: r.state,
+		Noise: r.noise,
+		NoiseAmount: r.noiseAmount,
+		Spare: r.spare,
+		HasSpare: r.hasSpare,
+	}
+}
+
+// Restore sets the internal state to a state returned by 'State'
+//
+// returns an error (and leaves the RNG unchanged) if the state is invalid,
+// because a state of all zeros would only ever produce 0
+func (r *RNG) Restore(state State) error {
+	if state.Main == [4]uint64{} {
+		return errors.New("rng: invalid state (all zeros)")
+	}
+	if state.NoiseAmount > 0 && state.Noise == [4]uint64{} {
+		return errors.New("rng: invalid noise state (all zeros)")
+	}
+	if state.NoiseAmount < 0 || state.NoiseAmount > 1 {
+		return errors.New("rng: invalid noise amount")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state = state.Main
+	r.noise = state.Noise
+	r.noiseAmount = state.NoiseAmount
+	r.spare = state.Spare
+	r.hasSpare = state.HasSpare
+
+	return nil
+}
+
+// Uint64 returns a random 64 bit number
+func (r *RNG) Uint64() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.next()
+}
+
+// Int63 returns a random non-negative 63 bit number
+//
+// together with 'Seed' and 'Uint64', this allows an RNG to be used as a math/rand Source
+func (r *RNG) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Intn returns a random number between 0 and n-1
+//
+// returns 0 if n <= 0
+func (r *RNG) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return int(r.uint64n(uint64(n)))
+}
+
+// Range returns a random number between min and max (including both min and max)
+func (r *RNG) Range(min, max int) int {
+	if min == max {
+		return min
+	}else if min > max {
+		min, max = max, min
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	span := uint64(max - min) + 1
+	if span == 0 {
+		// the range covers every possible number
+		return int(r.next())
+	}
+
+	return min + int(r.uint64n(span))
+}
+
+// Float64 returns a random number between 0 and 1 (not including 1)
+func (r *RNG) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.float64()
+}
+
+// RangeFloat returns a random number between min and max (not including max)
+func (r *RNG) RangeFloat(min, max float64) float64 {
+	if min > max {
+		min, max = max, min
+	}
+
+	return min + (r.Float64() * (max - min))
+}
+
+// Chance returns true with a probability between 0 and 1
+//
+// example: Chance(0.25) will return true about 1 in 4 times
+func (r *RNG) Chance(probability float64) bool {
+	return r.Float64() < probability
+}
+
+// Bool returns a random true or false
+func (r *RNG) Bool() bool {
+	return r.Uint64() & 1 == 1
+}
+
+// WeightedChoice returns a random index from a list of weights, where larger weights are more likely to be chosen
+//
+// weights of 0 or less are never chosen
+//
+// returns -1 if there are no positive weights
+//
+// example: WeightedChoice([]float64{1, 3}) returns 0 about 25% of the time, and 1 about 75% of the time
+func (r *RNG) WeightedChoice(weights []float64) int {
+	total := float64(0)
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+
+	if total <= 0 {
+		return -1
+	}
+
+	n := r.Float64() * total
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+
+		if n < w {
+			return i
+		}
+		n -= w
+		last = i
+	}
+
+	// float rounding can leave a tiny amount left over
+	return last
+}
+
+// Shuffle randomly reorders a list of n items using the swap callback
+//
+// example: r.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+func (r *RNG) Shuffle(n int, swap func(i, j int)){
+	for i := n - 1; i > 0; i-- {
+		swap(i, r.Intn(i + 1))
+	}
+}
+
+// Gaussian returns a random number from a normal distribution (bell curve)
+//
+// most numbers will be close to the mean, and about 68% of them will be within 1 stddev of it
+func (r *RNG) Gaussian(mean, stddev float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.hasSpare {
+		r.hasSpare = false
+		return mean + (r.spare * stddev)
+	}
+
+	// marsaglia polar method
+	for {
+		u := (r.float64() * 2) - 1
+		v := (r.float64() * 2) - 1
+		s := u * u + v * v
+		if s == 0 || s >= 1 {
+			continue
+		}
+
+		m := math.Sqrt(-2 * math.Log(s) / s)
+		r.spare = v * m
+		r.hasSpare = true
+		return mean + (u * m * stddev)
+	}
+}
+
+// next returns the next number, replacing it with a number from the noise source sometimes
+//
+// the main sequence always moves forward, so the pattern continues after any noise
+func (r *RNG) next() uint64 {
+	n := xoshiro(&r.state)
+
+	if r.noiseAmount > 0 && toFloat(xoshiro(&r.noise)) < r.noiseAmount {
+		n = xoshiro(&r.noise)
+	}
+
+	return n
+}
+
+func (r *RNG) float64() float64 {
+	return toFloat(r.next())
+}
+
+// uint64n returns an unbiased random number between 0 and n-1
+func (r *RNG) uint64n(n uint64) uint64 {
+	if n & (n - 1) == 0 {
+		return r.next() & (n - 1)
+	}
+
+	// reject numbers that would make the lower results more likely
+	threshold := -n % n
+	for {
+		hi, lo := bits.Mul64(r.next(), n)
+		if lo >= threshold {
+			return hi
+		}
+	}
+}
+
+func toFloat(n uint64) float64 {
+	return float64(n >> 11) / (1 << 53)
+}
+
+// seedState expands a seed into a full xoshiro256** state with splitmix64
+func seedState(seed int64) [4]uint64 {
+	s := uint64(seed)
+	state := [4]uint64{}
+	for i := range state {
+		s += 0x9e3779b97f4a7c15
+		z := s
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		state[i] = z ^ (z >> 31)
+	}
+	return state
+}
+
+// xoshiro moves a xoshiro256** state forward and returns the next number
+func xoshiro(s *[4]uint64) uint64 {
+	result := bits.RotateLeft64(s[1] * 5, 7) * 9
+	t := s[1] << 17
+
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+
+	return result
+}
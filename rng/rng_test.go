@@ -0,0 +1,171 @@
+package rng
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestSameSeed(t *testing.T) {
+	a := New(42)
+	b := New(42)
+	for i := 0; i < 1000; i++ {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("number %d does not match: %d != %d", i, x, y)
+		}
+	}
+
+	c := New(43)
+	a.Seed(43)
+	for i := 0; i < 100; i++ {
+		if x, y := a.Uint64(), c.Uint64(); x != y {
+			t.Fatalf("number %d does not match after Seed: %d != %d", i, x, y)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := New(1)
+
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		n := r.Range(1, 6)
+		if n < 1 || n > 6 {
+			t.Fatalf("Range(1, 6) returned %d", n)
+		}
+		seen[n] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("Range(1, 6) only returned %v", seen)
+	}
+
+	if n := r.Range(6, 1); n < 1 || n > 6 {
+		t.Errorf("Range(6, 1) returned %d", n)
+	}
+	if n := r.Range(3, 3); n != 3 {
+		t.Errorf("Range(3, 3) returned %d", n)
+	}
+	if n := r.Intn(0); n != 0 {
+		t.Errorf("Intn(0) returned %d", n)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if f := r.RangeFloat(-2, 2); f < -2 || f >= 2 {
+			t.Fatalf("RangeFloat(-2, 2) returned %g", f)
+		}
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	r := New(7)
+
+	count := [3]int{}
+	for i := 0; i < 10000; i++ {
+		n := r.WeightedChoice([]float64{1, 0, 3})
+		if n < 0 || n > 2 {
+			t.Fatalf("WeightedChoice returned %d", n)
+		}
+		count[n]++
+	}
+
+	if count[1] != 0 {
+		t.Errorf("a weight of 0 was chosen %d times", count[1])
+	}
+	if ratio := float64(count[2]) / float64(count[0]); ratio < 2.7 || ratio > 3.3 {
+		t.Errorf("expected a 1:3 ratio, found %d:%d", count[0], count[2])
+	}
+
+	if n := r.WeightedChoice([]float64{0, -1}); n != -1 {
+		t.Errorf("WeightedChoice with no positive weights returned %d", n)
+	}
+}
+
+func TestGaussian(t *testing.T) {
+	r := New(3)
+
+	sum := float64(0)
+	within := 0
+	for i := 0; i < 10000; i++ {
+		n := r.Gaussian(10, 2)
+		sum += n
+		if math.Abs(n - 10) < 2 {
+			within++
+		}
+	}
+
+	if mean := sum / 10000; math.Abs(mean - 10) > 0.1 {
+		t.Errorf("expected a mean of about 10, found %g", mean)
+	}
+	if within < 6500 || within > 7100 {
+		t.Errorf("expected about 68%% of numbers within 1 stddev, found %d of 10000", within)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	r := New(99)
+	r.SetNoise(5, 0.25)
+	r.Gaussian(0, 1) // leave a spare number
+
+	buf, err := json.Marshal(r.State())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []float64{}
+	for i := 0; i < 100; i++ {
+		expected = append(expected, r.Gaussian(0, 1), r.Float64())
+	}
+
+	state := State{}
+	if err := json.Unmarshal(buf, &state); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := New(0)
+	if err := restored.Restore(state); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		if g, f := restored.Gaussian(0, 1), restored.Float64(); g != expected[i*2] || f != expected[i*2+1] {
+			t.Fatalf("number %d does not match after Restore", i)
+		}
+	}
+}
+
+func TestNoise(t *testing.T) {
+	plain := New(11)
+	noisy := New(11)
+	noisy.SetNoise(22, 0.5)
+
+	same := 0
+	for i := 0; i < 1000; i++ {
+		if plain.Uint64() == noisy.Uint64() {
+			same++
+		}
+	}
+
+	// about half of the numbers should be replaced, and the pattern should continue after each one
+	if same < 400 || same > 600 {
+		t.Errorf("expected about 500 of 1000 numbers to match, found %d", same)
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	r := New(5)
+	expected := New(5)
+
+	if err := r.Restore(State{}); err == nil {
+		t.Errorf("an all zero state was restored")
+	}
+
+	state := r.State()
+	state.NoiseAmount = 0.5
+	if err := r.Restore(state); err == nil {
+		t.Errorf("an all zero noise state was restored")
+	}
+
+	// the RNG should not change after an invalid state
+	if r.Uint64() != expected.Uint64() {
+		t.Errorf("RNG changed after restoring an invalid state")
+	}
+}
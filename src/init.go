@@ -1,62 +1,30 @@
 package game
 
 import (
+	"game/enum/Loop"
 	"game/gamehandler"
-	"math"
-	"math/rand"
-	"strconv"
-	"strings"
+	"game/rng"
 	"time"
 )
 
-type randSeedHandler struct {
-	rand rand.Source
-	ind uint64
-}
-
-var GameRandSeed *randSeedHandler = &randSeedHandler{rand: rand.NewSource(rand.Int63())}
+var GameRandSeed *rng.RNG = rng.New(time.Now().UnixNano())
 var InconsistentRand bool = false
 
+// NoiseSeed is the seed used for the InconsistentRand noise
+//
+// save this with the game data, and set it again before Init runs, to reproduce the same inconsistencies in a replay
+//
+// if this is 0 when Init runs, a new seed is chosen from the current time
+var NoiseSeed int64 = 0
+
 func Init(game *gamehandler.Game){
 	InconsistentRand = game.InconsistentRand
 
 	//todo: may need to wait for level load menu
 
-	GameRandSeed.rand.Seed(6405275983374102578)
-
-	//todo: init game data and get level info
-
-	go func(){
-		for {
-			time.Sleep(1 * time.Second)
-	
-			//todo: add level objects each second
-	
-		}
-	}()
-}
-
-// Get a random number between the min and max based on the random seed
-func (randSeed *randSeedHandler) Get(min, max int) int {
-	if min == max {
-		return min
-	}else if min > max {
-		m := max
-		max = min
-		min = m
-	}
-
-	num := []byte(strconv.Itoa(int(randSeed.rand.Int63())))
-	
-	minL := len(strings.TrimPrefix(strconv.Itoa(min), "-"))
-	maxL := len(strings.TrimPrefix(strconv.Itoa(max), "-"))
+	GameRandSeed.Seed(6405275983374102578)
 
-	needL := maxL + (maxL-minL) + 2
-	for needL > len(num) {
-		num = append(num, []byte(strconv.Itoa(int(randSeed.rand.Int63())))...)
-	}
-
-	// InconsistentRand will randomly replace indexes with a new random int sometimes
+	// InconsistentRand will randomly replace some numbers with a number from a different random seed
 	//
 	// this can produce a random number from a consistant seed,
 	// giving a uniquely different result each time,
@@ -72,74 +40,25 @@ func (randSeed *randSeedHandler) Get(min, max int) int {
 	//
 	// for an easy difficulty mode, you could simply disable the 'InconsistentRand' option
 	//
-	// you can also play around with the math in this method to make things more or less consistant
+	// you can also play around with the noise amount to make things more or less consistant
+	//
+	// the noise also uses a seed, so a replay can reproduce the same inconsistencies by saving the noise seed (see 'NoiseSeed')
 	if InconsistentRand {
-		for i := range num {
-			r := strconv.Itoa(int(rand.Int31()))
-			if r[1] % 2 == 0 && ((r[2] % 2 == 0 || r[3] % 2 == 0) && (r[4] % 2 == 0 || r[5] % 2 == 0) || (r[2] % 2 == 0 || r[4] % 2 == 0)) {
-				num[i] = r[0]
-			}
+		if NoiseSeed == 0 {
+			NoiseSeed = time.Now().UnixNano()
 		}
+		GameRandSeed.SetNoise(NoiseSeed, 0.1)
 	}
 
-	res := num[:maxL]
-	num = num[maxL:]
-
-	if minL < maxL {
-		r := res[minL:]
-		res = res[:minL]
-		rm := num[:(maxL-minL)]
-		num = num[(maxL-minL):]
-		for i, n := range rm {
-			if n % 2 == 0 {
-				res = append(res, r[i])
-			}
-		}
-	}
-
-	pos := true
-	if min < 0 && max >= 0 {
-		pos = num[0] % 2 == 0
-		num = num[1:]
-	}else if min >= 0 && max < 0 {
-		pos = num[0] % 2 != 0
-		num = num[1:]
-	}else if min < 0 && max < 0 {
-		pos = false
-	}
-
-	if n, err := strconv.Atoi(string(res)); err == nil {
-		dif := int(num[0])
-		num = num[1:]
-		if dif <= 0 {
-			minA := int(math.Abs(float64(min)))
-			dif = int(math.Abs(float64(max)))-minA
-			if dif > minA && minA >= 0 {
-				dif = minA
-			}
-		}
-		if dif <= 0 {
-			dif = 1
-		}
-		for n > max {
-			n -= dif
-		}
-		for n < min {
-			n += dif
-		}
+	//todo: init game data and get level info
 
-		if n > max {
-			n = max
-		}else if n < min {
-			n = min
-		}
+	game.OnShutdown(func(game *gamehandler.Game) {
+		//todo: autosave game data
 
-		if !pos {
-			n *= -1
-		}
+	})
 
-		return n
-	}
+	game.Every(1 * time.Second, Loop.UpdateBasic, func(game *gamehandler.Game, thread *gamehandler.ThreadInfo) {
+		//todo: add level objects each second
 
-	return min
+	})
 }
@@ -14,7 +14,7 @@ func init(){
 	gamehandler.InitObject(func(game *gamehandler.Game) {
 		size := float32(4)
 
-		r := GameRandSeed.Get(0, 12)
+		r := GameRandSeed.Range(0, 12)
 		border := uint8(0)
 		if r % 2 == 0 {
 			border = 2
@@ -36,9 +36,9 @@ func init(){
 		}
 
 		if x == 0 {
-			x = float32(GameRandSeed.Get(int(-game.Size.Width + size), int(game.Size.Width - size)))
+			x = float32(GameRandSeed.Range(int(-game.Size.Width + size), int(game.Size.Width - size)))
 		}else if y == 0 {
-			y = float32(GameRandSeed.Get(int(-game.Size.Height + size), int(game.Size.Height - size)))
+			y = float32(GameRandSeed.Range(int(-game.Size.Height + size), int(game.Size.Height - size)))
 		}
 
 		object := game.Add("object", "obj1", x, y, size, size, func(game *gamehandler.Game) fyne.CanvasObject {
@@ -5,7 +5,6 @@ import (
 	"game/enum/CollisionMethod"
 	"game/gamehandler"
 	"image/color"
-	"os"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -17,7 +16,7 @@ func init(){
 	gamehandler.InitObject(func(game *gamehandler.Game) {
 		// (game.Size.Width / game.Size.Scale / 2), (game.Size.Height / game.Size.Scale / 2)
 		object := game.Add("player", "player", 0, 0, 5, 5, func(game *gamehandler.Game) fyne.CanvasObject {
-			if icon, err := os.ReadFile("./assets/objects/player/white.png"); err == nil {
+			if icon, err := gamehandler.ReadFile("./assets/objects/player/white.png"); err == nil {
 				res := canvas.NewImageFromResource(fyne.NewStaticResource("icon", icon))
 				return res
 			}
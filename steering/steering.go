@@ -0,0 +1,290 @@
+package steering
+
+import (
+	"game/gamehandler"
+	"game/rng"
+	"math"
+)
+
+// Vec is a 2D vector used for positions, velocities and steering forces
+type Vec struct {
+	X float32
+	Y float32
+}
+
+// Add returns the sum of 2 vectors
+func (v Vec) Add(v2 Vec) Vec {
+	return Vec{v.X + v2.X, v.Y + v2.Y}
+}
+
+// Sub returns the difference of 2 vectors
+func (v Vec) Sub(v2 Vec) Vec {
+	return Vec{v.X - v2.X, v.Y - v2.Y}
+}
+
+// Scale multiplies a vector by a number
+func (v Vec) Scale(n float32) Vec {
+	return Vec{v.X * n, v.Y * n}
+}
+
+// Len returns the length of a vector
+func (v Vec) Len() float32 {
+	return float32(math.Sqrt(float64(v.X * v.X + v.Y * v.Y)))
+}
+
+// Normalize returns a vector in the same direction with a length of 1
+func (v Vec) Normalize() Vec {
+	l := v.Len()
+	if l == 0 {
+		return Vec{}
+	}
+	return Vec{v.X / l, v.Y / l}
+}
+
+// Limit shortens a vector if it is longer than max
+func (v Vec) Limit(max float32) Vec {
+	if l := v.Len(); l > max && l != 0 {
+		return v.Scale(max / l)
+	}
+	return v
+}
+
+// Pos returns the position of an object in the world
+func Pos(object *gamehandler.GameObject) Vec {
+	x, y := object.WorldPos()
+	return Vec{x, y}
+}
+
+// Vel returns the velocity of an object
+func Vel(object *gamehandler.GameObject) Vec {
+	return Vec{object.VelX, object.VelY}
+}
+
+// Apply adds a steering force to the velocity of an object
+//
+// the velocity is limited to maxSpeed
+//
+// example: steering.Apply(enemy, steering.Seek(enemy, steering.Pos(player), 3).Add(steering.Separation(enemy, enemies, 6, 3)), 3)
+func Apply(object *gamehandler.GameObject, force Vec, maxSpeed float32){
+	vel := Vel(object).Add(force).Limit(maxSpeed)
+	object.VelX = vel.X
+	object.VelY = vel.Y
+}
+
+// Seek returns a steering force that moves an object towards a target position
+func Seek(object *gamehandler.GameObject, target Vec, maxSpeed float32) Vec {
+	desired := target.Sub(Pos(object)).Normalize().Scale(maxSpeed)
+	return desired.Sub(Vel(object))
+}
+
+// SeekObject returns a steering force that moves an object towards a target object
+func SeekObject(object *gamehandler.GameObject, target *gamehandler.GameObject, maxSpeed float32) Vec {
+	dir := target.GetDirection(object)
+	desired := Vec{dir.DirX, dir.DirY}.Scale(maxSpeed)
+	return desired.Sub(Vel(object))
+}
+
+// Flee returns a steering force that moves an object away from a target position
+func Flee(object *gamehandler.GameObject, target Vec, maxSpeed float32) Vec {
+	desired := Pos(object).Sub(target).Normalize().Scale(maxSpeed)
+	return desired.Sub(Vel(object))
+}
+
+// FleeObject returns a steering force that moves an object away from a target object
+func FleeObject(object *gamehandler.GameObject, target *gamehandler.GameObject, maxSpeed float32) Vec {
+	dir := object.GetDirection(target)
+	desired := Vec{dir.DirX, dir.DirY}.Scale(maxSpeed)
+	return desired.Sub(Vel(object))
+}
+
+// Arrive returns a steering force that moves an object towards a target position,
+// and slows it down as it gets within the slowRadius of the target
+func Arrive(object *gamehandler.GameObject, target Vec, maxSpeed float32, slowRadius float32) Vec {
+	offset := target.Sub(Pos(object))
+	dist := offset.Len()
+	if dist == 0 {
+		return Vel(object).Scale(-1)
+	}
+
+	speed := maxSpeed
+	if dist < slowRadius {
+		speed = maxSpeed * (dist / slowRadius)
+	}
+
+	desired := offset.Scale(speed / dist)
+	return desired.Sub(Vel(object))
+}
+
+// Pursue returns a steering force that moves an object towards where a moving target will be
+func Pursue(object *gamehandler.GameObject, target *gamehandler.GameObject, maxSpeed float32) Vec {
+	return Seek(object, predict(object, target, maxSpeed), maxSpeed)
+}
+
+// Evade returns a steering force that moves an object away from where a moving target will be
+func Evade(object *gamehandler.GameObject, target *gamehandler.GameObject, maxSpeed float32) Vec {
+	return Flee(object, predict(object, target, maxSpeed), maxSpeed)
+}
+
+// predict guesses where a target will be by the time an object can reach it
+func predict(object *gamehandler.GameObject, target *gamehandler.GameObject, maxSpeed float32) Vec {
+	if maxSpeed <= 0 {
+		return Pos(target)
+	}
+
+	dir := target.GetDirection(object)
+	ahead := dir.Dist / maxSpeed
+	return Pos(target).Add(Vel(target).Scale(ahead))
+}
+
+// Wanderer creates random but smooth movement by steering towards a point on a circle in front of an object
+type Wanderer struct {
+	// Distance is how far in front of the object the circle is
+	Distance float32
+
+	// Radius is the size of the circle
+	Radius float32
+
+	// Jitter is the max amount the point on the circle can move each update (in radians)
+	Jitter float64
+
+	angle float64
+	rand *rng.RNG
+}
+
+// NewWanderer creates a new wander behavior
+//
+// seed: the random seed used for choosing directions
+func NewWanderer(seed int64) *Wanderer {
+	r := rng.New(seed)
+
+	return &Wanderer{
+		Distance: 6,
+		Radius: 3,
+		Jitter: 0.5,
+		angle: r.Float64() * math.Pi * 2,
+		rand: r,
+	}
+}
+
+// Wander returns a steering force that moves an object around randomly
+func (wanderer *Wanderer) Wander(object *gamehandler.GameObject, maxSpeed float32) Vec {
+	wanderer.angle += (wanderer.rand.Float64() * 2 - 1) * wanderer.Jitter
+
+	heading := Vel(object).Normalize()
+	if heading == (Vec{}) {
+		heading = Vec{1, 0}
+	}
+
+	center := Pos(object).Add(heading.Scale(wanderer.Distance))
+	offset := Vec{float32(math.Cos(wanderer.angle)), float32(math.Sin(wanderer.angle))}.Scale(wanderer.Radius)
+
+	return Seek(object, center.Add(offset), maxSpeed)
+}
+
+// Separation returns a steering force that moves an object away from any neighbors that are too close
+//
+// radius: how close a neighbor needs to be to push the object away
+func Separation(object *gamehandler.GameObject, neighbors []*gamehandler.GameObject, radius float32, maxSpeed float32) Vec {
+	sum := Vec{}
+	count := 0
+
+	for _, other := range neighbors {
+		if other == object {
+			continue
+		}
+
+		dir := object.GetDirection(other)
+		if dir.Dist == 0 || dir.Dist > radius {
+			continue
+		}
+
+		// closer neighbors push harder
+		sum = sum.Add(Vec{dir.DirX, dir.DirY}.Scale(1 / dir.Dist))
+		count++
+	}
+
+	if count == 0 {
+		return Vec{}
+	}
+
+	desired := sum.Normalize().Scale(maxSpeed)
+	return desired.Sub(Vel(object))
+}
+
+// Alignment returns a steering force that turns an object to move in the same direction as its neighbors
+//
+// radius: how close a neighbor needs to be to be included
+func Alignment(object *gamehandler.GameObject, neighbors []*gamehandler.GameObject, radius float32, maxSpeed float32) Vec {
+	sum := Vec{}
+	count := 0
+
+	for _, other := range neighbors {
+		if other == object || object.GetDistance(other) > radius {
+			continue
+		}
+
+		sum = sum.Add(Vel(other))
+		count++
+	}
+
+	if count == 0 {
+		return Vec{}
+	}
+
+	desired := sum.Normalize().Scale(maxSpeed)
+	return desired.Sub(Vel(object))
+}
+
+// Cohesion returns a steering force that moves an object towards the center of its neighbors
+//
+// radius: how close a neighbor needs to be to be included
+func Cohesion(object *gamehandler.GameObject, neighbors []*gamehandler.GameObject, radius float32, maxSpeed float32) Vec {
+	sum := Vec{}
+	count := 0
+
+	for _, other := range neighbors {
+		if other == object || object.GetDistance(other) > radius {
+			continue
+		}
+
+		sum = sum.Add(Pos(other))
+		count++
+	}
+
+	if count == 0 {
+		return Vec{}
+	}
+
+	return Seek(object, sum.Scale(1 / float32(count)), maxSpeed)
+}
+
+// FlockWeights sets how much each flocking behavior affects an object
+type FlockWeights struct {
+	Separation float32
+	Alignment float32
+	Cohesion float32
+
+	// Radius is how close a neighbor needs to be to be included
+	Radius float32
+
+	// SeparationRadius is how close a neighbor needs to be to push the object away
+	SeparationRadius float32
+}
+
+// DefaultFlock is a balanced set of flocking weights
+var DefaultFlock = FlockWeights{
+	Separation: 1.5,
+	Alignment: 1,
+	Cohesion: 1,
+	Radius: 20,
+	SeparationRadius: 8,
+}
+
+// Flock returns a steering force that combines separation, alignment and cohesion
+//
+// example: steering.Apply(bird, steering.Flock(bird, game.GetType("bird"), steering.DefaultFlock, 3), 3)
+func Flock(object *gamehandler.GameObject, neighbors []*gamehandler.GameObject, weights FlockWeights, maxSpeed float32) Vec {
+	return Separation(object, neighbors, weights.SeparationRadius, maxSpeed).Scale(weights.Separation).
+		Add(Alignment(object, neighbors, weights.Radius, maxSpeed).Scale(weights.Alignment)).
+		Add(Cohesion(object, neighbors, weights.Radius, maxSpeed).Scale(weights.Cohesion))
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
 	"game/gamehandler"
-	"os"
+	"game/lang"
+	"game/mods"
+	"log"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -17,10 +20,16 @@ func main(){
 	// set default config
 	maxFPS := uint16(120)
 	inconsistentRand := true
+	debug := false
+	profile := false
+	modsDir := "./mods"
+	language := "auto"
+	fallbackLanguage := "en"
+	languages := []string{"en"}
 	objectTypes := []string{"object"}
 
 	// get game config file
-	if gameConfigFile, err := os.ReadFile("./src/config.yml"); err == nil {
+	if gameConfigFile, err := gamehandler.ReadFile("./src/config.yml"); err == nil {
 		gameConfig := map[string]interface{}{}
 		if err := yaml.Unmarshal(gameConfigFile, &gameConfig); err == nil {
 			if val, ok := gameConfig["MaxFPS"]; ok {
@@ -29,10 +38,40 @@ func main(){
 				}
 			}
 
-			if val, ok := gameConfig["MaxFPS"]; ok {
+			if val, ok := gameConfig["InconsistentRand"]; ok {
 				inconsistentRand = goutil.ToType[bool](val)
 			}
 			
+			if val, ok := gameConfig["Debug"]; ok {
+				debug = goutil.ToType[bool](val)
+			}
+
+			if val, ok := gameConfig["Profile"]; ok {
+				profile = goutil.ToType[bool](val)
+			}
+
+			if val, ok := gameConfig["Mods"]; ok {
+				modsDir = goutil.ToType[string](val)
+			}
+
+			if val, ok := gameConfig["Language"]; ok {
+				if v := goutil.ToType[string](val); v != "" {
+					language = v
+				}
+			}
+
+			if val, ok := gameConfig["FallbackLanguage"]; ok {
+				if v := goutil.ToType[string](val); v != "" {
+					fallbackLanguage = v
+				}
+			}
+
+			if val, ok := gameConfig["Languages"]; ok {
+				if v := goutil.ToType[[]string](val); len(v) != 0 {
+					languages = v
+				}
+			}
+
 			if val, ok := gameConfig["ObjectTypes"]; ok {
 				if v := goutil.ToType[[]string](val); len(v) != 0 {
 					objectTypes = v
@@ -41,19 +80,42 @@ func main(){
 		}
 	}
 
+	// load mods
+	if modsDir != "" {
+		if report := mods.Load(modsDir); len(report.Mods) != 0 || len(report.Errors) != 0 || len(report.Conflicts) != 0 {
+			log.Println(report)
+		}
+	}
+
+	// load languages
+	if err := lang.Load(languages...); err != nil {
+		log.Println(err)
+	}
+	lang.SetFallback(fallbackLanguage)
+	if language == "auto" {
+		language = lang.Detect()
+	}
+	if language == "" {
+		language = fallbackLanguage
+	}
+	lang.SetLanguage(language)
 
 	// create app and window
 	a := app.New()
 	defer a.Quit()
 
-	w := a.NewWindow("Test")
+	w := a.NewWindow(lang.T("title"))
 	defer w.Close()
 
+	lang.OnChange(func(code string) {
+		w.SetTitle(lang.T("title"))
+	})
+
 	// get background image
 	var img *canvas.Image
-	if bg, err := os.ReadFile("./assets/background.jpg"); err == nil {
+	if bg, err := gamehandler.ReadFile("./assets/background.jpg"); err == nil {
 		img = canvas.NewImageFromResource(fyne.NewStaticResource("background", bg))
-	}else if bg, err := os.ReadFile("./assets/background.png"); err == nil {
+	}else if bg, err := gamehandler.ReadFile("./assets/background.png"); err == nil {
 		img = canvas.NewImageFromResource(fyne.NewStaticResource("background", bg))
 	}
 
@@ -67,11 +129,14 @@ func main(){
 	}
 	canvasBox := container.NewWithoutLayout(canvasListArr...)
 
+	// create HUD overlay above the game canvas
+	hud := gamehandler.NewHUD()
+
 	var box *fyne.Container
 	if img != nil {
-		box = container.NewMax(img, canvasBox)
+		box = container.NewMax(img, canvasBox, hud.Container)
 	}else{
-		box = container.NewMax(canvasBox)
+		box = container.NewMax(canvasBox, hud.Container)
 	}
 	w.SetContent(box)
 
@@ -82,7 +147,7 @@ func main(){
 	// w.SetFullScreen(true)
 	w.SetMaster()
 
-	if icon, err := os.ReadFile("./assets/icon.png"); err == nil {
+	if icon, err := gamehandler.ReadFile("./assets/icon.png"); err == nil {
 		res := fyne.NewStaticResource("icon", icon)
 		a.SetIcon(res)
 		w.SetIcon(res)
@@ -103,6 +168,7 @@ func main(){
 		CanvasList: canvasList,
 		CanvasListKeys: canvasListKeys,
 		Window: w,
+		HUD: hud,
 
 		Size: gamehandler.CanvasSize{
 			RealWidth: canvasWidth + 0.000025,
@@ -117,9 +183,26 @@ func main(){
 		InconsistentRand: inconsistentRand,
 	}
 
-	go func(){
+	// F3 toggles the debug overlay
+	gameData.SetDebug(debug)
+	gameData.SetProfiling(profile)
+	w.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
+		if key.Name == fyne.KeyF3 {
+			gameData.ToggleDebug()
+		}
+	})
+
+	// update the canvas size when the window is resized
+	gameData.Go(func(ctx context.Context) {
+		ticker := time.NewTicker(300 * time.Millisecond)
+		defer ticker.Stop()
+
 		for {
-			time.Sleep(300 * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
 
 			canvasWidth := canvasBox.Size().Width
 			canvasHeight := canvasBox.Size().Height
@@ -143,20 +226,46 @@ func main(){
 			}
 			gameData.MU.Unlock()
 		}
-	}()
+	})
+
+	gameData.Go(func(ctx context.Context) {
+		Init(ctx, &gameData)
+	})
 
-	go Init(&gameData)
+	// stop the game loops and run the OnShutdown callbacks before the window closes
+	w.SetCloseIntercept(func() {
+		gameData.Shutdown()
+		w.Close()
+	})
 
 	w.ShowAndRun()
+
+	gameData.Shutdown()
 }
 
+// maxCatchUpFrames is the number of late frames a game loop will run back to back before it skips ahead
+//
+// this prevents a slow frame from causing a long burst of catch up frames
+const maxCatchUpFrames = 5
+
 // GameLoop creates a new game loop
 //
-// call this with `go GameLoop(...)` to run this on a new thread/goroutine
+// call this with `gameData.Go(...)` to run this on a new thread/goroutine, which stops when the game shuts down
 //
-// this method has been modified from the notch game loop
-func GameLoop(gameData *gamehandler.Game, fps uint16, cb func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)){
-	time.Sleep(100 * time.Millisecond)
+// the loop sleeps until each tick is due, and ticks are scheduled from the start time,
+// so small delays in waking up do not add up over time
+//
+// if the loop falls behind, late ticks run back to back (up to maxCatchUpFrames) to catch up,
+// and any ticks past that limit are skipped and reported in ThreadInfo.Skipped
+func GameLoop(ctx context.Context, gameData *gamehandler.Game, fps uint16, cb func(game *gamehandler.Game, thread *gamehandler.ThreadInfo)){
+	timer := time.NewTimer(100 * time.Millisecond)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
 
 	speedDelta := float32(1)
 	if fps > gameData.MaxFPS {
@@ -164,36 +273,54 @@ func GameLoop(gameData *gamehandler.Game, fps uint16, cb func(game *gamehandler.
 		fps = gameData.MaxFPS
 	}
 
-	lastTime := float64(time.Now().UnixNano())
-	ns := float64(time.Second.Nanoseconds()) / float64(fps)
-	delta := float64(0)
-	frames := uint16(0)
-	timeMS := float64(time.Now().UnixMilli())
+	interval := time.Second / time.Duration(fps)
+	next := time.Now().Add(interval)
 
+	frames := uint16(0)
+	fpsTime := time.Now()
 	currentFPS := fps
 
 	for {
-		now := float64(time.Now().UnixNano())
-		delta += (now - lastTime) / ns
-		lastTime = now
-
-		if delta >= 1 {
-			gameData.MU.Lock()
-			cb(gameData, &gamehandler.ThreadInfo{
-				FPS: currentFPS,
-				Frame: frames,
-				SpeedDelta: speedDelta,
-			})
+		if wait := time.Until(next); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}else if ctx.Err() != nil {
+			return
+		}
 
-			gameData.MU.Unlock()
+		// count how many ticks should have already run
+		late := time.Since(next) / interval
+		skipped := time.Duration(0)
+		if late > maxCatchUpFrames {
+			skipped = late - maxCatchUpFrames
+			late = maxCatchUpFrames
+			next = next.Add(skipped * interval)
+		}
 
-			frames++
-			delta--
-			if float64(time.Now().UnixMilli()) - timeMS >= float64(time.Second.Milliseconds()) {
-				currentFPS = frames
-				timeMS += float64(time.Second.Milliseconds())
-				frames = 0
-			}
+		lockStart := time.Now()
+		gameData.MU.Lock()
+		cb(gameData, &gamehandler.ThreadInfo{
+			FPS: currentFPS,
+			Frame: frames,
+			SpeedDelta: speedDelta,
+			Delta: interval,
+			LockWait: time.Since(lockStart),
+			Late: uint16(late),
+			Skipped: uint16(skipped),
+		})
+		gameData.MU.Unlock()
+
+		next = next.Add(interval)
+
+		frames++
+		if time.Since(fpsTime) >= time.Second {
+			currentFPS = frames
+			fpsTime = fpsTime.Add(time.Second)
+			frames = 0
 		}
 	}
 }